@@ -13,6 +13,10 @@ type ApplicationConfig struct {
 	Name           string
 	ServiceStarter rscsrv.ServiceStarter
 	HTTP           FasthttpServiceConfiguration
+
+	// Validator is used by the router of the application when its
+	// `RouterConfig` does not define one.
+	Validator Validator
 }
 
 type Application struct {
@@ -32,6 +36,10 @@ func NewApplication(config ApplicationConfig, router Router) *Application {
 		app.fasthttpService.Server.Name = fmt.Sprintf("fasthttp/%s", config.Name)
 	}
 
+	if config.Validator != nil {
+		setDefaultValidator(router, config.Validator)
+	}
+
 	app.fasthttpService.Server.Handler = router.Handler()
 	app.done = make(chan bool, 1)
 	app.signals = make(chan os.Signal, 1)
@@ -0,0 +1,331 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lab259/errors/v2"
+)
+
+var (
+	BindErrorCode    = "bind"
+	BindErrorMessage = "We could not read the data of your request."
+
+	// ErrBind is the reason of the errors returned by `Request.Bind`.
+	ErrBind = errors.New("bind failed")
+)
+
+// BindError reports the fields that could not be bound by `Request.Bind`. It
+// is sent as a 400 by `Response.Error`, listing the reasons for each field.
+type BindError struct {
+	errors map[string][]string
+}
+
+func (err *BindError) add(field, reason string) {
+	if err.errors == nil {
+		err.errors = make(map[string][]string)
+	}
+	err.errors[field] = append(err.errors[field], reason)
+}
+
+// Errors returns the reasons each field could not be bound, eg.
+// `{"page": ["invalid"]}`.
+func (err *BindError) Errors() map[string][]string {
+	return err.errors
+}
+
+func (err *BindError) Code() string {
+	return BindErrorCode
+}
+
+func (err *BindError) Message() string {
+	return BindErrorMessage
+}
+
+func (err *BindError) AppendData(response errors.ErrorResponse) {
+	response.SetParam("code", err.Code())
+	response.SetParam("message", err.Message())
+	response.SetParam("statusCode", StatusBadRequest)
+	response.SetParam("errors", err.errors)
+}
+
+func (err *BindError) Unwrap() error {
+	return ErrBind
+}
+
+func (err *BindError) Error() string {
+	fields := make([]string, 0, len(err.errors))
+	for field := range err.errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var buff bytes.Buffer
+	for _, field := range fields {
+		if buff.Len() > 0 {
+			buff.WriteString("; ")
+		}
+		buff.WriteString(fmt.Sprintf(`"%s" is %s`, field, strings.Join(err.errors[field], ", ")))
+	}
+	return fmt.Sprintf("%s: %s", ErrBind.Error(), buff.String())
+}
+
+// bindSources are the tags `Bind` reads the fields from.
+var bindSources = []string{"param", "query", "header", "form"}
+
+type bindField struct {
+	index  []int
+	source string
+	name   string
+	// layout is used to parse `time.Time` fields, `time.RFC3339` by default.
+	layout string
+}
+
+var bindFieldsCache sync.Map
+
+// bindFields returns the tagged fields of the struct type `t`, including the
+// fields of its embedded structs.
+func bindFields(t reflect.Type) []bindField {
+	if fields, ok := bindFieldsCache.Load(t); ok {
+		return fields.([]bindField)
+	}
+	fields := appendBindFields(nil, t, nil)
+	bindFieldsCache.Store(t, fields)
+	return fields
+}
+
+func appendBindFields(fields []bindField, t reflect.Type, index []int) []bindField {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			// Unexported
+			continue
+		}
+		fieldIndex := append(index[:len(index):len(index)], i)
+
+		tagged := false
+		for _, source := range bindSources {
+			if name, ok := f.Tag.Lookup(source); ok && name != "-" {
+				fields = append(fields, bindField{
+					index:  fieldIndex,
+					source: source,
+					name:   name,
+					layout: f.Tag.Get("layout"),
+				})
+				tagged = true
+				break
+			}
+		}
+		if !tagged && f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = appendBindFields(fields, f.Type, fieldIndex)
+		}
+	}
+	return fields
+}
+
+// bindValues returns the values of the field from its source. Headers are
+// split by `,` when bound to slices.
+func bindValues(req Request, field *bindField, slice bool) []string {
+	var values [][]byte
+	switch field.source {
+	case "param":
+		if v := req.Param(field.name); v != "" {
+			return []string{v}
+		}
+		return nil
+	case "query":
+		values = req.QueryMulti(field.name)
+	case "header":
+		v := req.Header(field.name)
+		if len(v) == 0 {
+			return nil
+		}
+		if !slice {
+			return []string{string(v)}
+		}
+		values = bytes.Split(v, []byte{','})
+		for i := range values {
+			values[i] = bytes.TrimSpace(values[i])
+		}
+	case "form":
+		values = req.PostMulti(field.name)
+		if len(values) == 0 {
+			if form, err := req.MultipartForm(); err == nil {
+				return form.Value[field.name]
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// bindValue converts `s` into the type of `v`, setting it.
+func bindValue(v reflect.Value, s string, layout string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return bindValue(v.Elem(), s, layout)
+	}
+
+	switch v.Type() {
+	case timeType:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot bind to %s", v.Type())
+	}
+	return nil
+}
+
+// bindTarget returns the struct pointed by `dst`.
+func bindTarget(dst interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return v, fmt.Errorf("cannot bind to %T: a pointer to a struct is required", dst)
+	}
+	return v.Elem(), nil
+}
+
+// bindTagged binds the fields of `v` tagged with the `source`, or with any
+// source when it is empty.
+func bindTagged(req Request, v reflect.Value, source string, bindErr *BindError) {
+	fields := bindFields(v.Type())
+	for i := range fields {
+		field := &fields[i]
+		if source != "" && field.source != source {
+			continue
+		}
+		f := v.FieldByIndex(field.index)
+		// Slices unmarshaling text, as `net.IP`, are bound from one value
+		slice := f.Kind() == reflect.Slice && !reflect.PtrTo(f.Type()).Implements(textUnmarshalerType)
+		values := bindValues(req, field, slice)
+		if len(values) == 0 {
+			continue
+		}
+
+		if !slice {
+			if err := bindValue(f, values[0], field.layout); err != nil {
+				bindErr.add(field.name, "invalid")
+			}
+			continue
+		}
+
+		s := reflect.MakeSlice(f.Type(), len(values), len(values))
+		valid := true
+		for j, value := range values {
+			if err := bindValue(s.Index(j), value, field.layout); err != nil {
+				bindErr.add(field.name, "invalid")
+				valid = false
+				break
+			}
+		}
+		if valid {
+			f.Set(s)
+		}
+	}
+}
+
+// bind fills the struct pointed by `dst` with the body of the request,
+// decoded according to its `Content-Type`, as JSON without it, and then with
+// the values of the tagged fields. Forms are read only through the `form`
+// tags.
+func bind(req Request, dst interface{}) error {
+	v, err := bindTarget(dst)
+	if err != nil {
+		return err
+	}
+
+	bindErr := &BindError{}
+	if body := req.Raw().PostBody(); len(body) > 0 {
+		if !isFormMediaType(parseMediaType(req.Header("Content-Type"))) {
+			if err := decode(req, dst); errors.Is(err, ErrUnsupportedMediaType) {
+				return err
+			} else if err != nil {
+				if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+					bindErr.add(typeErr.Field, "invalid")
+				} else {
+					bindErr.add("body", "malformed")
+				}
+			}
+		}
+	}
+
+	if _, err := req.MultipartForm(); errors.Is(err, ErrRequestEntityTooLarge) {
+		return err
+	}
+	bindTagged(req, v, "", bindErr)
+
+	if len(bindErr.errors) > 0 {
+		return bindErr
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net"
+	"time"
+
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type bindPagination struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+type bindTodoRequest struct {
+	bindPagination
+	ID          int64         `param:"id"`
+	Tenant      string        `header:"X-Tenant"`
+	Languages   []string      `header:"Accept-Language"`
+	Tags        []string      `query:"tag"`
+	Done        *bool         `query:"done"`
+	Since       time.Time     `query:"since" layout:"2006-01-02"`
+	Until       *time.Time    `query:"until"`
+	Timeout     time.Duration `query:"timeout"`
+	IP          net.IP        `query:"ip"`
+	Priorities  []uint8       `query:"priority"`
+	Description string        `json:"description"`
+	Owner       string        `json:"owner" param:"owner"`
+	ignored     string        `query:"ignored"`
+}
+
+var _ = Describe("Hermes", func() {
+	Describe("Bind", func() {
+		var (
+			router Router
+			dst    bindTodoRequest
+			err    error
+		)
+
+		BeforeEach(func() {
+			dst = bindTodoRequest{}
+			err = nil
+			router = DefaultRouter()
+			handler := func(req Request, res Response) Result {
+				err = req.Bind(&dst)
+				return res.Error(err)
+			}
+			router.Put("/todos/:id", handler)
+			router.Put("/users/:owner/todos/:id", handler)
+		})
+
+		It("should bind params, query and headers", func() {
+			ctx := createRequestCtxFromPath("PUT", "/todos/42")
+			ctx.Request.URI().SetQueryString("page=2&per_page=10&tag=a&tag=b&done=true&since=2019-10-18&until=2019-10-19T10:00:00Z&timeout=2s&ip=127.0.0.1&priority=1&priority=3&ignored=1")
+			ctx.Request.Header.Set("X-Tenant", "acme")
+			ctx.Request.Header.Set("Accept-Language", "en, pt-BR")
+
+			router.Handler()(ctx)
+
+			Expect(err).ToNot(HaveOccurred())
+			done := true
+			until := time.Date(2019, 10, 19, 10, 0, 0, 0, time.UTC)
+			Expect(dst).To(Equal(bindTodoRequest{
+				bindPagination: bindPagination{Page: 2, PerPage: 10},
+				ID:             42,
+				Tenant:         "acme",
+				Languages:      []string{"en", "pt-BR"},
+				Tags:           []string{"a", "b"},
+				Done:           &done,
+				Since:          time.Date(2019, 10, 18, 0, 0, 0, 0, time.UTC),
+				Until:          &until,
+				Timeout:        2 * time.Second,
+				IP:             net.ParseIP("127.0.0.1"),
+				Priorities:     []uint8{1, 3},
+			}))
+		})
+
+		It("should bind the JSON body before the tagged fields", func() {
+			ctx := createRequestCtxFromPath("PUT", "/users/snake-eyes/todos/42")
+			ctx.Request.Header.SetContentType("application/json")
+			ctx.Request.SetBodyString(`{"description": "write tests", "owner": "storm-shadow"}`)
+
+			router.Handler()(ctx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dst.ID).To(Equal(int64(42)))
+			Expect(dst.Description).To(Equal("write tests"))
+			Expect(dst.Owner).To(Equal("snake-eyes"))
+		})
+
+		It("should bind url encoded forms", func() {
+			type form struct {
+				Name  string   `form:"name"`
+				Roles []string `form:"role"`
+			}
+			var f form
+
+			req := newRequest()
+			req.Raw().Request.Header.SetContentType("application/x-www-form-urlencoded")
+			req.Raw().Request.SetBodyString("name=Snake+Eyes&role=ninja&role=commando")
+
+			Expect(req.Bind(&f)).To(Succeed())
+			Expect(f).To(Equal(form{Name: "Snake Eyes", Roles: []string{"ninja", "commando"}}))
+		})
+
+		It("should bind multipart forms", func() {
+			type form struct {
+				Name string `form:"name"`
+				Age  int    `form:"age"`
+			}
+			var f form
+
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			Expect(w.WriteField("name", "Snake Eyes")).To(Succeed())
+			Expect(w.WriteField("age", "36")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+
+			req := newRequest()
+			req.Raw().Request.Header.SetMethod("POST")
+			req.Raw().Request.Header.SetContentType(w.FormDataContentType())
+			req.Raw().Request.SetBody(body.Bytes())
+
+			Expect(req.Bind(&f)).To(Succeed())
+			Expect(f).To(Equal(form{Name: "Snake Eyes", Age: 36}))
+		})
+
+		It("should report the fields that could not be bound", func() {
+			ctx := createRequestCtxFromPath("PUT", "/todos/snake")
+			ctx.Request.URI().SetQueryString("page=first&tag=a&done=maybe&since=yesterday&priority=1&priority=300")
+
+			router.Handler()(ctx)
+
+			Expect(errors.Is(err, ErrBind)).To(BeTrue())
+			bindErr, ok := err.(*BindError)
+			Expect(ok).To(BeTrue())
+			Expect(bindErr.Errors()).To(Equal(map[string][]string{
+				"id":       {"invalid"},
+				"page":     {"invalid"},
+				"done":     {"invalid"},
+				"since":    {"invalid"},
+				"priority": {"invalid"},
+			}))
+			Expect(bindErr.Error()).To(Equal(`bind failed: "done" is invalid; "id" is invalid; "page" is invalid; "priority" is invalid; "since" is invalid`))
+			Expect(dst.Tags).To(Equal([]string{"a"}))
+			Expect(dst.Priorities).To(BeNil())
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusBadRequest))
+			var body map[string]interface{}
+			Expect(json.Unmarshal(ctx.Response.Body(), &body)).To(Succeed())
+			Expect(body).To(Equal(map[string]interface{}{
+				"code":    BindErrorCode,
+				"message": BindErrorMessage,
+				"errors": map[string]interface{}{
+					"id":       []interface{}{"invalid"},
+					"page":     []interface{}{"invalid"},
+					"done":     []interface{}{"invalid"},
+					"since":    []interface{}{"invalid"},
+					"priority": []interface{}{"invalid"},
+				},
+			}))
+		})
+
+		It("should report invalid JSON bodies", func() {
+			ctx := createRequestCtxFromPath("PUT", "/todos/42")
+			ctx.Request.Header.SetContentType("application/json")
+			ctx.Request.SetBodyString(`{"description": 42}`)
+			router.Handler()(ctx)
+
+			Expect(err).To(BeAssignableToTypeOf(&BindError{}))
+			Expect(err.(*BindError).Errors()).To(Equal(map[string][]string{"description": {"invalid"}}))
+			Expect(dst.ID).To(Equal(int64(42)))
+
+			ctx = createRequestCtxFromPath("PUT", "/todos/42")
+			ctx.Request.Header.SetContentType("application/json")
+			ctx.Request.SetBodyString(`{"description":`)
+			router.Handler()(ctx)
+
+			Expect(err).To(BeAssignableToTypeOf(&BindError{}))
+			Expect(err.(*BindError).Errors()).To(Equal(map[string][]string{"body": {"malformed"}}))
+		})
+
+		It("should fail binding to something other than a pointer to a struct", func() {
+			req := newRequest()
+			var s string
+			Expect(req.Bind(s)).To(MatchError("cannot bind to string: a pointer to a struct is required"))
+			Expect(req.Bind(&s)).To(MatchError("cannot bind to *string: a pointer to a struct is required"))
+			Expect(req.Bind((*bindTodoRequest)(nil))).To(HaveOccurred())
+		})
+
+		It("should bind the body as JSON when the request has no content type", func() {
+			req := newRequest()
+			req.Raw().Request.SetBodyString(`{"description": "write tests"}`)
+
+			Expect(req.Bind(&dst)).To(Succeed())
+			Expect(dst.Description).To(Equal("write tests"))
+
+			req = newRequest()
+			req.Raw().Request.SetBodyString(`{"description":`)
+
+			err := req.Bind(&dst)
+			Expect(err).To(BeAssignableToTypeOf(&BindError{}))
+			Expect(err.(*BindError).Errors()).To(Equal(map[string][]string{"body": {"malformed"}}))
+		})
+
+		It("should fail binding bodies without a decoder", func() {
+			req := newRequest()
+			req.Raw().Request.Header.SetContentType("text/plain")
+			req.Raw().Request.SetBodyString("write tests")
+
+			Expect(errors.Is(req.Bind(&dst), ErrUnsupportedMediaType)).To(BeTrue())
+			Expect(dst.Description).To(BeEmpty())
+		})
+	})
+})
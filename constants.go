@@ -1,5 +1,13 @@
 package hermes
 
+// The patterns returned by `Request.RoutePattern` when no route was matched.
+const (
+	PatternNotFound         = "<not found>"
+	PatternMethodNotAllowed = "<method not allowed>"
+	PatternOptions          = "<options>"
+	PatternRedirect         = "<redirect>"
+)
+
 var (
 	applicationJSON        = []byte("application/json")
 	defaultJSONContentType = []byte("application/json; charset=utf-8")
@@ -0,0 +1,123 @@
+package hermes
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type paramConstraint struct {
+	expr  string
+	match func([]byte) bool
+}
+
+// paramConstraints are the named constraints that can be used instead of a
+// regular expression, eg. `/users/:id<int>`.
+var paramConstraints = map[string]func([]byte) bool{
+	"int":   isInt,
+	"uint":  isUint,
+	"alpha": isAlpha,
+	"alnum": isAlnum,
+	"uuid":  isUUID,
+}
+
+// parseParam splits a wildcard token (without the leading `:`) into its name
+// and constraint. Tokens like `id` have no constraint, while `id<int>` and
+// `name<[a-z0-9-]+>` are constrained by a named constraint or by a regular
+// expression, which must match the whole token.
+func parseParam(path string, token []byte) (string, *paramConstraint) {
+	lt := -1
+	for i, c := range token {
+		if c == '<' {
+			lt = i
+			break
+		}
+	}
+	if lt == -1 {
+		return string(token), nil
+	}
+
+	if token[len(token)-1] != '>' || lt+2 >= len(token) {
+		panic(fmt.Sprintf("invalid constraint in '%s'", path))
+	}
+
+	expr := string(token[lt+1 : len(token)-1])
+	constraint := &paramConstraint{
+		expr:  expr,
+		match: paramConstraints[expr],
+	}
+	if constraint.match == nil {
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			panic(fmt.Sprintf("invalid constraint in '%s': %s", path, err))
+		}
+		constraint.match = re.Match
+	}
+	return string(token[:lt]), constraint
+}
+
+func isUint(token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isInt(token []byte) bool {
+	if len(token) > 0 && (token[0] == '-' || token[0] == '+') {
+		token = token[1:]
+	}
+	return isUint(token)
+}
+
+func isAlpha(token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+	for _, c := range token {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(token []byte) bool {
+	if len(token) == 0 {
+		return false
+	}
+	for _, c := range token {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// isUUID checks the canonical 8-4-4-4-12 textual representation.
+func isUUID(token []byte) bool {
+	if len(token) != 36 {
+		return false
+	}
+	for i, c := range token {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isHex(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
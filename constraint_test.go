@@ -0,0 +1,52 @@
+package hermes
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Constraint", func() {
+		It("should parse a wildcard without constraint", func() {
+			name, constraint := parseParam("/:id", []byte("id"))
+			Expect(name).To(Equal("id"))
+			Expect(constraint).To(BeNil())
+		})
+
+		It("should parse a named constraint", func() {
+			name, constraint := parseParam("/:id<int>", []byte("id<int>"))
+			Expect(name).To(Equal("id"))
+			Expect(constraint.expr).To(Equal("int"))
+			Expect(constraint.match([]byte("-12"))).To(BeTrue())
+			Expect(constraint.match([]byte("12a"))).To(BeFalse())
+		})
+
+		It("should parse a regular expression constraint", func() {
+			name, constraint := parseParam("/:name<[a-z0-9-]+>", []byte("name<[a-z0-9-]+>"))
+			Expect(name).To(Equal("name"))
+			Expect(constraint.expr).To(Equal("[a-z0-9-]+"))
+			Expect(constraint.match([]byte("file-01"))).To(BeTrue())
+			Expect(constraint.match([]byte("File-01"))).To(BeFalse())
+		})
+
+		It("should anchor regular expressions", func() {
+			_, constraint := parseParam("/:year<[0-9]{4}>", []byte("year<[0-9]{4}>"))
+			Expect(constraint.match([]byte("2019"))).To(BeTrue())
+			Expect(constraint.match([]byte("20199"))).To(BeFalse())
+		})
+
+		It("should match the named constraints", func() {
+			Expect(isInt([]byte("+1"))).To(BeTrue())
+			Expect(isInt([]byte("-"))).To(BeFalse())
+			Expect(isUint([]byte("123"))).To(BeTrue())
+			Expect(isUint([]byte("-123"))).To(BeFalse())
+			Expect(isAlpha([]byte("abcXYZ"))).To(BeTrue())
+			Expect(isAlpha([]byte("abc1"))).To(BeFalse())
+			Expect(isAlnum([]byte("abc1"))).To(BeTrue())
+			Expect(isAlnum([]byte("abc-1"))).To(BeFalse())
+			Expect(isUUID([]byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))).To(BeTrue())
+			Expect(isUUID([]byte("6ba7b810-9dad-11d1-80b4-00c04fd430cz"))).To(BeFalse())
+			Expect(isUUID([]byte("6ba7b8109dad11d180b400c04fd430c8"))).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,172 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/lab259/errors/v2"
+	"github.com/vmihailenco/msgpack/v4"
+)
+
+var (
+	UnsupportedMediaTypeErrorCode = "unsupported-media-type"
+
+	// ErrUnsupportedMediaType is returned by `Request.Data` and
+	// `Request.Bind` when there is no decoder for the `Content-Type` of the
+	// request. It is reported as a 415.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Decoder decodes the body of a request into `dst`.
+type Decoder interface {
+	Decode(req Request, dst interface{}) error
+}
+
+// DecoderFunc adapts a function into a `Decoder`.
+type DecoderFunc func(req Request, dst interface{}) error
+
+func (fn DecoderFunc) Decode(req Request, dst interface{}) error {
+	return fn(req, dst)
+}
+
+var (
+	decodersMutex sync.RWMutex
+	decoders      = map[string]Decoder{
+		"application/json":                  DecoderFunc(decodeJSON),
+		"application/xml":                   DecoderFunc(decodeXML),
+		"text/xml":                          DecoderFunc(decodeXML),
+		"application/msgpack":               DecoderFunc(decodeMsgpack),
+		"application/x-msgpack":             DecoderFunc(decodeMsgpack),
+		"application/x-www-form-urlencoded": DecoderFunc(decodeForm),
+		"multipart/form-data":               DecoderFunc(decodeForm),
+	}
+)
+
+// RegisterDecoder registers the decoder used for requests whose
+// `Content-Type` is the `mediaType`, eg. `application/yaml`, replacing the
+// current one, if any.
+func RegisterDecoder(mediaType string, decoder Decoder) {
+	decodersMutex.Lock()
+	defer decodersMutex.Unlock()
+	decoders[strings.ToLower(mediaType)] = decoder
+}
+
+// findDecoder returns the decoder of the `mediaType`. Media types with a
+// structured syntax suffix, eg. `application/problem+json`, fall back to the
+// decoder of the suffix.
+func findDecoder(mediaType string) (Decoder, bool) {
+	decodersMutex.RLock()
+	defer decodersMutex.RUnlock()
+	if decoder, ok := decoders[mediaType]; ok {
+		return decoder, true
+	}
+	if i := strings.LastIndexByte(mediaType, '+'); i > -1 {
+		decoder, ok := decoders["application/"+mediaType[i+1:]]
+		return decoder, ok
+	}
+	return nil, false
+}
+
+// parseMediaType returns the media type of a `Content-Type`, lower cased and
+// without its parameters.
+func parseMediaType(contentType []byte) string {
+	if i := bytes.IndexByte(contentType, ';'); i > -1 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(string(bytes.TrimSpace(contentType)))
+}
+
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
+func unsupportedMediaType(mediaType string) error {
+	return errors.Wrap(
+		ErrUnsupportedMediaType,
+		errors.Http(StatusUnsupportedMediaType),
+		errors.Code(UnsupportedMediaTypeErrorCode),
+		errors.Message(fmt.Sprintf("The media type '%s' is not supported.", mediaType)),
+	)
+}
+
+// decode decodes the body of the request according to its `Content-Type`.
+// Requests without it are decoded as JSON.
+func decode(req Request, dst interface{}) error {
+	mediaType := parseMediaType(req.Header("Content-Type"))
+	if mediaType == "" {
+		return decodeJSON(req, dst)
+	}
+	decoder, ok := findDecoder(mediaType)
+	if !ok {
+		return unsupportedMediaType(mediaType)
+	}
+	return decoder.Decode(req, dst)
+}
+
+func decodeJSON(req Request, dst interface{}) error {
+	return currentJSONCodec().Unmarshal(req.Raw().PostBody(), dst)
+}
+
+func decodeXML(req Request, dst interface{}) error {
+	return xml.Unmarshal(req.Raw().PostBody(), dst)
+}
+
+func decodeMsgpack(req Request, dst interface{}) error {
+	return msgpack.Unmarshal(req.Raw().PostBody(), dst)
+}
+
+// decodeForm decodes url encoded and multipart forms into a
+// `map[string]string`, a `map[string][]string` or the fields of a struct
+// tagged with `form`.
+func decodeForm(req Request, dst interface{}) error {
+	if _, err := req.MultipartForm(); errors.Is(err, ErrRequestEntityTooLarge) {
+		return err
+	}
+
+	switch m := dst.(type) {
+	case *map[string]string:
+		if *m == nil {
+			*m = make(map[string]string)
+		}
+		for key, values := range formValues(req) {
+			(*m)[key] = values[0]
+		}
+		return nil
+	case *map[string][]string:
+		if *m == nil {
+			*m = make(map[string][]string)
+		}
+		for key, values := range formValues(req) {
+			(*m)[key] = values
+		}
+		return nil
+	}
+
+	v, err := bindTarget(dst)
+	if err != nil {
+		return err
+	}
+	bindErr := &BindError{}
+	bindTagged(req, v, "form", bindErr)
+	if len(bindErr.errors) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+// formValues returns all the values of the url encoded or multipart form.
+func formValues(req Request) map[string][]string {
+	values := make(map[string][]string)
+	req.Raw().PostArgs().VisitAll(func(key, value []byte) {
+		values[string(key)] = append(values[string(key)], string(value))
+	})
+	if form, err := req.MultipartForm(); err == nil {
+		for key, v := range form.Value {
+			values[key] = append(values[key], v...)
+		}
+	}
+	return values
+}
@@ -0,0 +1,169 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"strings"
+
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmihailenco/msgpack/v4"
+)
+
+type decodedUser struct {
+	Name  string   `json:"name" xml:"name" msgpack:"name" form:"name"`
+	Age   int      `json:"age" xml:"age" msgpack:"age" form:"age"`
+	Roles []string `json:"roles" xml:"role" msgpack:"roles" form:"role"`
+}
+
+var _ = Describe("Hermes", func() {
+	Describe("Decoders", func() {
+		newRequestWithBody := func(contentType string, body []byte) *BaseRequest {
+			req := newRequest()
+			req.Raw().Request.Header.SetMethod("POST")
+			if contentType != "" {
+				req.Raw().Request.Header.SetContentType(contentType)
+			}
+			req.Raw().Request.SetBody(body)
+			return req
+		}
+
+		expected := decodedUser{Name: "Snake Eyes", Age: 36, Roles: []string{"ninja", "commando"}}
+
+		It("should decode JSON", func() {
+			body := []byte(`{"name": "Snake Eyes", "age": 36, "roles": ["ninja", "commando"]}`)
+			for _, contentType := range []string{"", "application/json", "application/json; charset=utf-8", "Application/JSON", "application/vnd.api+json"} {
+				var user decodedUser
+				Expect(newRequestWithBody(contentType, body).Data(&user)).To(Succeed(), contentType)
+				Expect(user).To(Equal(expected), contentType)
+			}
+		})
+
+		It("should decode XML", func() {
+			body := []byte(`<user><name>Snake Eyes</name><age>36</age><role>ninja</role><role>commando</role></user>`)
+			for _, contentType := range []string{"application/xml", "text/xml; charset=utf-8", "application/atom+xml"} {
+				var user decodedUser
+				Expect(newRequestWithBody(contentType, body).Data(&user)).To(Succeed(), contentType)
+				Expect(user).To(Equal(expected), contentType)
+			}
+		})
+
+		It("should decode MessagePack", func() {
+			body, err := msgpack.Marshal(&expected)
+			Expect(err).ToNot(HaveOccurred())
+			for _, contentType := range []string{"application/msgpack", "application/x-msgpack"} {
+				var user decodedUser
+				Expect(newRequestWithBody(contentType, body).Data(&user)).To(Succeed(), contentType)
+				Expect(user).To(Equal(expected), contentType)
+			}
+		})
+
+		It("should decode url encoded forms", func() {
+			body := []byte("name=Snake+Eyes&age=36&role=ninja&role=commando")
+			contentType := "application/x-www-form-urlencoded"
+
+			var user decodedUser
+			Expect(newRequestWithBody(contentType, body).Data(&user)).To(Succeed())
+			Expect(user).To(Equal(expected))
+
+			var values map[string]string
+			Expect(newRequestWithBody(contentType, body).Data(&values)).To(Succeed())
+			Expect(values).To(Equal(map[string]string{"name": "Snake Eyes", "age": "36", "role": "ninja"}))
+
+			var multi map[string][]string
+			Expect(newRequestWithBody(contentType, body).Data(&multi)).To(Succeed())
+			Expect(multi).To(Equal(map[string][]string{"name": {"Snake Eyes"}, "age": {"36"}, "role": {"ninja", "commando"}}))
+		})
+
+		It("should decode multipart forms", func() {
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			Expect(w.WriteField("name", "Snake Eyes")).To(Succeed())
+			Expect(w.WriteField("age", "36")).To(Succeed())
+			Expect(w.WriteField("role", "ninja")).To(Succeed())
+			Expect(w.WriteField("role", "commando")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+
+			var user decodedUser
+			Expect(newRequestWithBody(w.FormDataContentType(), body.Bytes()).Data(&user)).To(Succeed())
+			Expect(user).To(Equal(expected))
+		})
+
+		It("should report form fields that cannot be decoded", func() {
+			var user decodedUser
+			err := newRequestWithBody("application/x-www-form-urlencoded", []byte("age=old")).Data(&user)
+			Expect(err).To(BeAssignableToTypeOf(&BindError{}))
+			Expect(err.(*BindError).Errors()).To(Equal(map[string][]string{"age": {"invalid"}}))
+		})
+
+		It("should fail decoding unsupported media types", func() {
+			var user decodedUser
+			err := newRequestWithBody("text/csv", []byte("name,age")).Data(&user)
+			Expect(errors.Is(err, ErrUnsupportedMediaType)).To(BeTrue())
+
+			err = newRequestWithBody("text/csv", []byte("name,age")).Bind(&user)
+			Expect(errors.Is(err, ErrUnsupportedMediaType)).To(BeTrue())
+		})
+
+		It("should respond unsupported media types with a 415", func() {
+			router := DefaultRouter()
+			router.Post("/users", func(req Request, res Response) Result {
+				var user decodedUser
+				if err := req.Data(&user); err != nil {
+					return res.Error(err)
+				}
+				return res.Data(&user)
+			})
+
+			ctx := createRequestCtxFromPath("POST", "/users")
+			ctx.Request.Header.SetContentType("text/csv")
+			ctx.Request.SetBodyString("name,age")
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusUnsupportedMediaType))
+			var body map[string]interface{}
+			Expect(json.Unmarshal(ctx.Response.Body(), &body)).To(Succeed())
+			Expect(body).To(Equal(map[string]interface{}{
+				"code":    UnsupportedMediaTypeErrorCode,
+				"message": "The media type 'text/csv' is not supported.",
+			}))
+		})
+
+		It("should use registered decoders", func() {
+			RegisterDecoder("Application/X-Hermes-Test", DecoderFunc(func(req Request, dst interface{}) error {
+				fields := strings.Split(string(req.Raw().PostBody()), ",")
+				dst.(*decodedUser).Name = fields[0]
+				dst.(*decodedUser).Roles = fields[1:]
+				return nil
+			}))
+			defer func() {
+				decodersMutex.Lock()
+				delete(decoders, "application/x-hermes-test")
+				decodersMutex.Unlock()
+			}()
+
+			var user decodedUser
+			Expect(newRequestWithBody("application/x-hermes-test", []byte("Snake Eyes,ninja")).Data(&user)).To(Succeed())
+			Expect(user).To(Equal(decodedUser{Name: "Snake Eyes", Roles: []string{"ninja"}}))
+		})
+
+		It("should bind bodies of any supported media type", func() {
+			type input struct {
+				ID   int    `param:"id"`
+				Name string `xml:"name"`
+			}
+			req := newRequestWithBody("application/xml", []byte(`<user><name>Snake Eyes</name></user>`))
+			req.validParams = []string{"id"}
+			req.params = [][]byte{[]byte("42")}
+
+			var in input
+			Expect(req.Bind(&in)).To(Succeed())
+			Expect(in).To(Equal(input{ID: 42, Name: "Snake Eyes"}))
+
+			req = newRequestWithBody("application/xml", []byte(`<user><name>`))
+			Expect(req.Bind(&in)).To(BeAssignableToTypeOf(&BindError{}))
+		})
+	})
+})
@@ -0,0 +1,245 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/lab259/errors/v2"
+	"github.com/vmihailenco/msgpack/v4"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	NotAcceptableErrorCode    = "not-acceptable"
+	NotAcceptableErrorMessage = "We could not respond in any of the media types you accept."
+
+	// ErrNotAcceptable is the reason of the error sent by `Response.Data`
+	// when none of the encoders is accepted by the request. It is reported
+	// as a 406.
+	ErrNotAcceptable = errors.Wrap(
+		errors.New("not acceptable"),
+		errors.Http(StatusNotAcceptable),
+		errors.Code(NotAcceptableErrorCode),
+		errors.Message(NotAcceptableErrorMessage),
+	)
+)
+
+// Encoder encodes the data sent by `Response.Data`.
+type Encoder interface {
+	Encode(w io.Writer, v interface{}) error
+}
+
+// EncoderFunc adapts a function into an `Encoder`.
+type EncoderFunc func(w io.Writer, v interface{}) error
+
+func (fn EncoderFunc) Encode(w io.Writer, v interface{}) error {
+	return fn(w, v)
+}
+
+type encoderEntry struct {
+	mediaType   string
+	contentType []byte
+	encoder     Encoder
+}
+
+var (
+	jsonEncoder = &encoderEntry{"application/json", defaultJSONContentType, EncoderFunc(encodeJSON)}
+
+	encodersMutex sync.RWMutex
+	// encoders are sorted by preference, used when the request accepts
+	// more than one of them with the same quality, eg. `*/*`.
+	encoders = []*encoderEntry{
+		jsonEncoder,
+		{"application/xml", []byte("application/xml; charset=utf-8"), EncoderFunc(encodeXML)},
+		{"text/xml", []byte("text/xml; charset=utf-8"), EncoderFunc(encodeXML)},
+		{"application/msgpack", []byte("application/msgpack"), EncoderFunc(encodeMsgpack)},
+		{"application/x-msgpack", []byte("application/x-msgpack"), EncoderFunc(encodeMsgpack)},
+		{"application/yaml", []byte("application/yaml; charset=utf-8"), EncoderFunc(encodeYAML)},
+		{"application/x-yaml", []byte("application/x-yaml; charset=utf-8"), EncoderFunc(encodeYAML)},
+		{"text/yaml", []byte("text/yaml; charset=utf-8"), EncoderFunc(encodeYAML)},
+		{"application/cbor", []byte("application/cbor"), EncoderFunc(encodeCBOR)},
+	}
+)
+
+// RegisterEncoder registers the encoder used for requests accepting the
+// `mediaType`, replacing the current one, if any. New encoders have the least
+// preference.
+func RegisterEncoder(mediaType string, encoder Encoder) {
+	encodersMutex.Lock()
+	defer encodersMutex.Unlock()
+
+	mediaType = strings.ToLower(mediaType)
+	for _, entry := range encoders {
+		if entry.mediaType == mediaType {
+			entry.encoder = encoder
+			return
+		}
+	}
+	encoders = append(encoders, &encoderEntry{mediaType, []byte(mediaType), encoder})
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+	// specificity is 2 for `type/subtype`, 1 for `type/*` and 0 for `*/*`.
+	specificity int
+}
+
+// parseAccept parses the media ranges of an `Accept` header. Ranges with an
+// invalid quality are ignored.
+func parseAccept(accept []byte) []acceptRange {
+	ranges := make([]acceptRange, 0, 4)
+	for _, part := range bytes.Split(accept, []byte{','}) {
+		params := bytes.Split(part, []byte{';'})
+		mediaType := strings.ToLower(string(bytes.TrimSpace(params[0])))
+		slash := strings.IndexByte(mediaType, '/')
+		if slash < 1 || slash == len(mediaType)-1 {
+			continue
+		}
+
+		r := acceptRange{mediaType: mediaType, q: 1, specificity: 2}
+		if mediaType == "*/*" {
+			r.specificity = 0
+		} else if mediaType[slash+1:] == "*" {
+			r.specificity = 1
+		}
+
+		valid := true
+		for _, param := range params[1:] {
+			param = bytes.TrimSpace(param)
+			if len(param) < 2 || (param[0] != 'q' && param[0] != 'Q') || param[1] != '=' {
+				continue
+			}
+			q, err := strconv.ParseFloat(string(param[2:]), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+			}
+			r.q = q
+		}
+		if valid {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// matches checks if the `mediaType` is in the range.
+func (r *acceptRange) matches(mediaType string) bool {
+	switch r.specificity {
+	case 0:
+		return true
+	case 1:
+		return strings.HasPrefix(mediaType, r.mediaType[:len(r.mediaType)-1])
+	}
+	return r.mediaType == mediaType
+}
+
+// quality returns the quality of the `mediaType` for the `ranges`, given by
+// the most specific range matching it, the first one when tied.
+func quality(ranges []acceptRange, mediaType string) (match *acceptRange, order int) {
+	for i := range ranges {
+		r := &ranges[i]
+		if r.matches(mediaType) && (match == nil || r.specificity > match.specificity) {
+			match, order = r, i
+		}
+	}
+	return
+}
+
+type negotiated struct {
+	entry *encoderEntry
+	match *acceptRange
+	order int
+}
+
+// negotiate returns the encoders accepted by the `Accept` header, sorted from
+// the best match, only JSON when it is empty. The encoders are compared by their quality, then by how
+// specific is the range they matched, then by the order of the ranges and,
+// finally, by their preference. Browsers, which accept `text/html`, get JSON
+// first when they accept it, as the XML they accept is not meant for APIs.
+func negotiate(accept []byte) []*encoderEntry {
+	if len(bytes.TrimSpace(accept)) == 0 {
+		return []*encoderEntry{jsonEncoder}
+	}
+	ranges := parseAccept(accept)
+
+	encodersMutex.RLock()
+	defer encodersMutex.RUnlock()
+
+	candidates := make([]negotiated, 0, len(encoders))
+	for _, entry := range encoders {
+		match, order := quality(ranges, entry.mediaType)
+		if match == nil || match.q == 0 {
+			continue
+		}
+		candidates = append(candidates, negotiated{entry, match, order})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.match.q != b.match.q {
+			return a.match.q > b.match.q
+		}
+		if a.match.specificity != b.match.specificity {
+			return a.match.specificity > b.match.specificity
+		}
+		return a.order < b.order
+	})
+
+	browser := false
+	for i := range ranges {
+		if ranges[i].mediaType == "text/html" && ranges[i].q > 0 {
+			browser = true
+			break
+		}
+	}
+
+	result := make([]*encoderEntry, len(candidates))
+	for i, c := range candidates {
+		result[i] = c.entry
+		if browser && c.entry == jsonEncoder {
+			copy(result[1:i+1], result[:i])
+			result[0] = jsonEncoder
+		}
+	}
+	return result
+}
+
+// acceptsJSON checks if the preferred media type of the `Accept` header,
+// ignoring wildcards, is JSON or has the `+json` suffix.
+func acceptsJSON(accept []byte) bool {
+	var preferred *acceptRange
+	ranges := parseAccept(accept)
+	for i := range ranges {
+		r := &ranges[i]
+		if r.specificity == 2 && r.q > 0 && (preferred == nil || r.q > preferred.q) {
+			preferred = r
+		}
+	}
+	return preferred != nil && (preferred.mediaType == "application/json" || strings.HasSuffix(preferred.mediaType, "+json"))
+}
+
+func encodeJSON(w io.Writer, v interface{}) error {
+	return currentJSONCodec().NewEncoder(w).Encode(v)
+}
+
+func encodeXML(w io.Writer, v interface{}) error {
+	return xml.NewEncoder(w).Encode(v)
+}
+
+func encodeMsgpack(w io.Writer, v interface{}) error {
+	return msgpack.NewEncoder(w).Encode(v)
+}
+
+func encodeYAML(w io.Writer, v interface{}) error {
+	return yaml.NewEncoder(w).Encode(v)
+}
+
+func encodeCBOR(w io.Writer, v interface{}) error {
+	return cbor.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,245 @@
+package hermes
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vmihailenco/msgpack/v4"
+	"gopkg.in/yaml.v2"
+)
+
+type encodedUser struct {
+	XMLName xml.Name `json:"-" xml:"user" msgpack:"-" yaml:"-" cbor:"-"`
+	Name    string   `json:"name" xml:"name" msgpack:"name" yaml:"name" cbor:"name"`
+	Age     int      `json:"age" xml:"age" msgpack:"age" yaml:"age" cbor:"age"`
+}
+
+var _ = Describe("Hermes", func() {
+	Describe("Encoders", func() {
+		user := encodedUser{Name: "Snake Eyes", Age: 36}
+
+		send := func(accept string, data interface{}) *BaseResponse {
+			res := newResponse()
+			if accept != "" {
+				res.result.r.Request.Header.Set("Accept", accept)
+			}
+			res.Data(data)
+			return res
+		}
+
+		contentType := func(res *BaseResponse) string {
+			return string(res.result.r.Response.Header.ContentType())
+		}
+
+		body := func(res *BaseResponse) []byte {
+			return res.result.r.Response.Body()
+		}
+
+		It("should encode JSON when there is no Accept", func() {
+			res := send("", &user)
+			Expect(contentType(res)).To(Equal("application/json; charset=utf-8"))
+			Expect(strings.TrimSpace(string(body(res)))).To(Equal(`{"name":"Snake Eyes","age":36}`))
+		})
+
+		It("should encode JSON for any media type", func() {
+			for _, accept := range []string{"*/*", "application/*", "text/html, */*;q=0.8"} {
+				Expect(contentType(send(accept, &user))).To(Equal("application/json; charset=utf-8"), accept)
+			}
+		})
+
+		It("should encode XML", func() {
+			res := send("application/xml", &user)
+			Expect(contentType(res)).To(Equal("application/xml; charset=utf-8"))
+			Expect(string(body(res))).To(Equal(`<user><name>Snake Eyes</name><age>36</age></user>`))
+
+			Expect(contentType(send("text/xml", &user))).To(Equal("text/xml; charset=utf-8"))
+		})
+
+		It("should encode MessagePack", func() {
+			res := send("application/msgpack", &user)
+			Expect(contentType(res)).To(Equal("application/msgpack"))
+			var decoded encodedUser
+			Expect(msgpack.Unmarshal(body(res), &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(user))
+
+			Expect(contentType(send("application/x-msgpack", &user))).To(Equal("application/x-msgpack"))
+		})
+
+		It("should encode YAML", func() {
+			res := send("application/yaml", &user)
+			Expect(contentType(res)).To(Equal("application/yaml; charset=utf-8"))
+			var decoded encodedUser
+			Expect(yaml.Unmarshal(body(res), &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(user))
+		})
+
+		It("should encode CBOR", func() {
+			res := send("application/cbor", &user)
+			Expect(contentType(res)).To(Equal("application/cbor"))
+			var decoded encodedUser
+			Expect(cbor.Unmarshal(body(res), &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(user))
+		})
+
+		It("should pick the media type with the highest quality", func() {
+			Expect(contentType(send("application/json;q=0.5, application/yaml", &user))).To(Equal("application/yaml; charset=utf-8"))
+			Expect(contentType(send("application/xml;q=0.9, application/cbor;q=0.95, */*;q=0.1", &user))).To(Equal("application/cbor"))
+		})
+
+		It("should pick the first media type listed when the quality is the same", func() {
+			Expect(contentType(send("application/cbor, application/json", &user))).To(Equal("application/cbor"))
+		})
+
+		It("should prefer the most specific range", func() {
+			Expect(contentType(send("*/*;q=0.8, application/*;q=0.9, application/xml", &user))).To(Equal("application/xml; charset=utf-8"))
+			// The specific range of JSON refuses it, even though `*/*` accepts it
+			Expect(contentType(send("application/json;q=0, */*", &user))).To(Equal("application/xml; charset=utf-8"))
+		})
+
+		It("should respond a 406 when no media type is acceptable", func() {
+			for _, accept := range []string{
+				"application/json;q=0", "text/html, application/json;q=0", "image/*, */*;q=0",
+				"text/html", "image/png", "image/*", "text/csv;q=0.5",
+			} {
+				res := send(accept, &user)
+				Expect(res.result.r.Response.StatusCode()).To(Equal(StatusNotAcceptable), accept)
+				Expect(contentType(res)).To(Equal("application/json; charset=utf-8"), accept)
+				var decoded map[string]interface{}
+				Expect(json.Unmarshal(body(res), &decoded)).To(Succeed(), accept)
+				Expect(decoded).To(Equal(map[string]interface{}{
+					"code":    NotAcceptableErrorCode,
+					"message": NotAcceptableErrorMessage,
+				}), accept)
+			}
+		})
+
+		It("should send JSON when any media type is acceptable", func() {
+			for _, accept := range []string{"", "*/*", "*/*;q=0.1"} {
+				res := send(accept, &user)
+				Expect(res.result.r.Response.StatusCode()).To(Equal(StatusOK), accept)
+				Expect(contentType(res)).To(Equal("application/json; charset=utf-8"), accept)
+			}
+		})
+
+		It("should try the next acceptable encoder when one cannot encode the data", func() {
+			res := send("application/xml, application/yaml;q=0.5", map[string]interface{}{"a": 1})
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusOK))
+			Expect(contentType(res)).To(Equal("application/yaml; charset=utf-8"))
+
+			res = send("application/xml, */*;q=0.1", struct{ A int }{1})
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusOK))
+			Expect(contentType(res)).To(Equal("application/json; charset=utf-8"))
+		})
+
+		It("should respond a 406 when no acceptable encoder can encode the data", func() {
+			res := send("application/xml, application/json;q=0", map[string]interface{}{"a": 1})
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusNotAcceptable))
+
+			res = send("application/xml", map[string]interface{}{"a": 1})
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusNotAcceptable))
+			Expect(contentType(res)).To(Equal("application/json; charset=utf-8"))
+		})
+
+		It("should send JSON to browsers", func() {
+			for _, accept := range []string{
+				"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+				"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7",
+				"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+			} {
+				for _, data := range []interface{}{
+					map[string]interface{}{"a": 1},
+					struct{ A int }{1},
+					&user,
+				} {
+					res := send(accept, data)
+					Expect(res.result.r.Response.StatusCode()).To(Equal(StatusOK), accept)
+					Expect(contentType(res)).To(Equal("application/json; charset=utf-8"), accept)
+				}
+			}
+		})
+
+		It("should send errors as JSON when they cannot be encoded as accepted", func() {
+			res := newResponse()
+			res.result.r.Request.Header.Set("Accept", "application/xml")
+			res.Error(errForced)
+
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusInternalServerError))
+			Expect(contentType(res)).To(Equal("application/json; charset=utf-8"))
+		})
+
+		It("should send errors in the accepted media type", func() {
+			res := newResponse()
+			res.result.r.Request.Header.Set("Accept", "application/yaml")
+			res.Error(errForced)
+
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusInternalServerError))
+			Expect(contentType(res)).To(Equal("application/yaml; charset=utf-8"))
+			var decoded map[string]interface{}
+			Expect(yaml.Unmarshal(body(res), &decoded)).To(Succeed())
+			Expect(decoded).To(HaveKeyWithValue("code", InternalServerErrorCode))
+		})
+
+		It("should use registered encoders", func() {
+			RegisterEncoder("Text/CSV", EncoderFunc(func(w io.Writer, v interface{}) error {
+				u := v.(*encodedUser)
+				_, err := io.WriteString(w, u.Name+",36")
+				return err
+			}))
+			defer func() {
+				encodersMutex.Lock()
+				encoders = encoders[:len(encoders)-1]
+				encodersMutex.Unlock()
+			}()
+
+			res := send("text/csv", &user)
+			Expect(contentType(res)).To(Equal("text/csv"))
+			Expect(string(body(res))).To(Equal("Snake Eyes,36"))
+			// Registered encoders have the least preference
+			Expect(contentType(send("*/*", &user))).To(Equal("application/json; charset=utf-8"))
+		})
+
+		It("should replace encoders", func() {
+			previous := encoders[5].encoder
+			RegisterEncoder("application/yaml", EncoderFunc(func(w io.Writer, v interface{}) error {
+				_, err := io.WriteString(w, "replaced")
+				return err
+			}))
+			defer func() {
+				encodersMutex.Lock()
+				encoders[5].encoder = previous
+				encodersMutex.Unlock()
+			}()
+
+			res := send("application/yaml", &user)
+			Expect(contentType(res)).To(Equal("application/yaml; charset=utf-8"))
+			Expect(string(body(res))).To(Equal("replaced"))
+		})
+
+		It("should ignore ranges with invalid qualities", func() {
+			Expect(parseAccept([]byte("application/json;q=2, text/*;q=abc, invalid, application/xml;level=1;q=0.5"))).To(Equal([]acceptRange{
+				{mediaType: "application/xml", q: 0.5, specificity: 2},
+			}))
+		})
+
+		It("should want JSON when it is preferred", func() {
+			req := newRequest()
+			for accept, expected := range map[string]bool{
+				"application/json":                      true,
+				"application/vnd.api+json":              true,
+				"text/html;q=0.9, application/json":     true,
+				"application/json;q=0":                  false,
+				"application/jsonp":                     false,
+				"*/*":                                   false,
+				"text/html;q=0.9, application/json;q=0": false,
+			} {
+				req.Raw().Request.Header.Set("Accept", accept)
+				Expect(req.WantsJSON()).To(Equal(expected), accept)
+			}
+		})
+	})
+})
@@ -0,0 +1,145 @@
+package hermes
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lab259/errors/v2"
+)
+
+var (
+	// ErrRouteNotFound is returned by `Router.URL` when there is no route
+	// registered with the given name.
+	ErrRouteNotFound = errors.New("route not found")
+
+	// ErrRouteParamMissing is returned by `Router.URL` when a param of the
+	// route was not informed.
+	ErrRouteParamMissing = errors.New("route param missing")
+)
+
+// RouteInfo describes a route registered on a `Router`.
+type RouteInfo struct {
+	Method string
+	// Host is the host pattern of the route, if registered through
+	// `Router.Host`.
+	Host string
+	Path string
+	Name string
+	// Params are the names of the wildcards and catch all of the route.
+	Params []string
+	// Middlewares is the number of middlewares applied to the route.
+	Middlewares int
+	// Meta is the metadata set through `Route.Meta`. It must not be
+	// modified.
+	Meta map[string]interface{}
+}
+
+type endpoint struct {
+	router      *router
+	method      string
+	host        string
+	path        string
+	name        string
+	params      []string
+	middlewares int
+	meta        map[string]interface{}
+}
+
+func (e *endpoint) Name(name string) Route {
+	if current, ok := e.router.names[name]; ok && (current.path != e.path || current.host != e.host) {
+		panic(fmt.Sprintf("conflict naming '%s' as '%s'", e.path, name))
+	}
+	e.name = name
+	e.router.names[name] = e
+	return e
+}
+
+func (e *endpoint) Meta(key string, value interface{}) Route {
+	if e.meta == nil {
+		e.meta = make(map[string]interface{})
+	}
+	e.meta[key] = value
+	return e
+}
+
+// endpoints are the routes registered at once for multiple methods.
+type endpoints []*endpoint
+
+func (routes endpoints) Name(name string) Route {
+	for _, e := range routes {
+		e.Name(name)
+	}
+	return routes
+}
+
+func (routes endpoints) Meta(key string, value interface{}) Route {
+	for _, e := range routes {
+		e.Meta(key, value)
+	}
+	return routes
+}
+
+func (e *endpoint) info() RouteInfo {
+	info := e.route()
+	info.Params = append([]string(nil), e.params...)
+	return info
+}
+
+// route describes the endpoint sharing its params, so nothing is allocated.
+func (e *endpoint) route() RouteInfo {
+	return RouteInfo{
+		Method:      e.method,
+		Host:        e.host,
+		Path:        e.path,
+		Name:        e.name,
+		Params:      e.params,
+		Middlewares: len(e.router.middlewares) + e.middlewares,
+		Meta:        e.meta,
+	}
+}
+
+// url builds the path of the endpoint replacing its wildcards and catch all
+// by the values in `params`, which are key value pairs.
+func (e *endpoint) url(params ...interface{}) (string, error) {
+	var buff bytes.Buffer
+	for _, token := range strings.Split(e.path[1:], "/") {
+		buff.WriteByte('/')
+		if len(token) == 0 || (token[0] != ':' && token[0] != '*') {
+			buff.WriteString(token)
+			continue
+		}
+
+		name := token[1:]
+		if i := strings.IndexByte(name, '<'); i > -1 {
+			name = name[:i]
+		}
+		value, ok := urlParam(name, params)
+		if !ok {
+			return "", errors.Wrap(ErrRouteParamMissing, errors.Message(fmt.Sprintf("missing param '%s' for route '%s'", name, e.path)))
+		}
+
+		if token[0] == ':' {
+			buff.WriteString(url.PathEscape(value))
+			continue
+		}
+		// Catch alls keep their slashes
+		for i, segment := range strings.Split(strings.TrimPrefix(value, "/"), "/") {
+			if i > 0 {
+				buff.WriteByte('/')
+			}
+			buff.WriteString(url.PathEscape(segment))
+		}
+	}
+	return buff.String(), nil
+}
+
+func urlParam(name string, params []interface{}) (string, bool) {
+	for i := 0; i+1 < len(params); i += 2 {
+		if fmt.Sprint(params[i]) == name {
+			return fmt.Sprint(params[i+1]), true
+		}
+	}
+	return "", false
+}
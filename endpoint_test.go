@@ -0,0 +1,262 @@
+package hermes
+
+import (
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Named routes", func() {
+		var router Router
+
+		BeforeEach(func() {
+			router = DefaultRouter()
+		})
+
+		It("should build the URL of a static route", func() {
+			router.Get("/todos", emptyHandler).Name("todos.index")
+
+			url, err := router.URL("todos.index")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/todos"))
+		})
+
+		It("should build the URL of the root route", func() {
+			router.Get("/", emptyHandler).Name("home")
+
+			url, err := router.URL("home")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/"))
+		})
+
+		It("should build the URL respecting prefixes", func() {
+			router.Prefix("/api").Prefix("/v1").Group(func(r Routable) {
+				r.Prefix("/todos").Get("/:id", emptyHandler).Name("todos.show")
+			})
+
+			url, err := router.URL("todos.show", "id", 42)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/api/v1/todos/42"))
+		})
+
+		It("should build the URL filling multiple params", func() {
+			router.Get("/accounts/:account/transactions/:id<int>", emptyHandler).Name("transactions.show")
+
+			url, err := router.URL("transactions.show", "id", 7, "account", "acc 1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/accounts/acc%201/transactions/7"))
+		})
+
+		It("should build the URL filling a catch all", func() {
+			router.Get("/static/*filepath", emptyHandler).Name("static")
+
+			url, err := router.URL("static", "filepath", "css/app main.css")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/static/css/app%20main.css"))
+		})
+
+		It("should fail due to a missing param", func() {
+			router.Get("/todos/:id", emptyHandler).Name("todos.show")
+
+			_, err := router.URL("todos.show", "todo", 42)
+			Expect(errors.Is(err, ErrRouteParamMissing)).To(BeTrue())
+		})
+
+		It("should fail due to an unknown route", func() {
+			_, err := router.URL("todos.show")
+			Expect(errors.Is(err, ErrRouteNotFound)).To(BeTrue())
+		})
+
+		It("should allow naming the same path for different methods", func() {
+			router.Get("/todos/:id", emptyHandler).Name("todos.show")
+			Expect(func() {
+				router.Put("/todos/:id", emptyHandler).Name("todos.show")
+			}).NotTo(Panic())
+		})
+
+		It("should panic due to conflicting names", func() {
+			router.Get("/todos", emptyHandler).Name("todos")
+			Expect(func() {
+				router.Get("/todos/:id", emptyHandler).Name("todos")
+			}).To(Panic())
+		})
+	})
+
+	Describe("Routes", func() {
+		var (
+			router     Router
+			middleware = func(req Request, res Response, next Handler) Result {
+				return next(req, res)
+			}
+		)
+
+		BeforeEach(func() {
+			router = DefaultRouter()
+		})
+
+		It("should list the registered routes", func() {
+			router.Use(middleware)
+			router.Get("/", emptyHandler).Name("home")
+			router.Prefix("/todos").Group(func(r Routable) {
+				r.Get("/", emptyHandler)
+				r.With(middleware).Put("/:id<int>", emptyHandler).Name("todos.update")
+			})
+			router.Get("/static/*filepath", emptyHandler, middleware, middleware)
+
+			Expect(router.Routes()).To(Equal([]RouteInfo{
+				{Method: "GET", Path: "/", Name: "home", Middlewares: 1},
+				{Method: "GET", Path: "/todos", Middlewares: 1},
+				{Method: "PUT", Path: "/todos/:id<int>", Name: "todos.update", Params: []string{"id"}, Middlewares: 2},
+				{Method: "GET", Path: "/static/*filepath", Params: []string{"filepath"}, Middlewares: 3},
+			}))
+		})
+
+		It("should walk the registered routes until an error", func() {
+			router.Get("/todos", emptyHandler)
+			router.Post("/todos", emptyHandler)
+			router.Delete("/todos/:id", emptyHandler)
+
+			methods := make([]string, 0)
+			err := router.Walk(func(route RouteInfo) error {
+				methods = append(methods, route.Method)
+				if route.Method == "POST" {
+					return errForced
+				}
+				return nil
+			})
+			Expect(err).To(Equal(errForced))
+			Expect(methods).To(Equal([]string{"GET", "POST"}))
+		})
+
+		It("should not list any route", func() {
+			Expect(router.Routes()).To(BeEmpty())
+		})
+	})
+
+	Describe("Route metadata", func() {
+		var (
+			router Router
+			routes []RouteInfo
+		)
+
+		middleware := func(req Request, res Response, next Handler) Result {
+			routes = append(routes, req.Route())
+			return next(req, res)
+		}
+
+		BeforeEach(func() {
+			router = DefaultRouter()
+			routes = make([]RouteInfo, 0)
+		})
+
+		It("should expose the matched route to the middlewares", func() {
+			router.Use(middleware)
+			router.Prefix("/todos").Put("/:id<int>", emptyHandler).Name("todos.update").Meta("scope", "todos:write")
+
+			router.Handler()(createRequestCtxFromPath("PUT", "/todos/1"))
+
+			Expect(routes).To(Equal([]RouteInfo{
+				{
+					Method:      "PUT",
+					Path:        "/todos/:id<int>",
+					Name:        "todos.update",
+					Params:      []string{"id"},
+					Middlewares: 1,
+					Meta:        map[string]interface{}{"scope": "todos:write"},
+				},
+			}))
+		})
+
+		It("should set the metadata for all the methods of Match", func() {
+			router.Use(middleware)
+			router.Match([]string{"GET", "HEAD"}, "/health", emptyHandler).Meta("public", true).Meta("metrics", false)
+
+			router.Handler()(createRequestCtxFromPath("HEAD", "/health"))
+
+			Expect(routes).To(HaveLen(1))
+			Expect(routes[0].Meta).To(Equal(map[string]interface{}{"public": true, "metrics": false}))
+			Expect(router.Routes()[0].Meta).To(Equal(routes[0].Meta))
+		})
+
+		It("should expose the route of a mounted router with its prefix", func() {
+			sub := DefaultRouter()
+			sub.Use(middleware)
+			sub.Get("/", emptyHandler).Meta("public", true)
+			sub.Get("/:id", emptyHandler)
+			router.Mount("/api/todos", sub)
+
+			router.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+			router.Handler()(createRequestCtxFromPath("GET", "/api/todos/1"))
+
+			Expect(routes).To(HaveLen(2))
+			Expect(routes[0].Path).To(Equal("/api/todos"))
+			Expect(routes[0].Meta).To(HaveKeyWithValue("public", true))
+			Expect(routes[1].Path).To(Equal("/api/todos/:id"))
+		})
+
+		It("should expose an empty route when no route is matched", func() {
+			router.Use(middleware)
+			router.Get("/todos", emptyHandler)
+
+			router.Handler()(createRequestCtxFromPath("GET", "/users"))
+			router.Handler()(createRequestCtxFromPath("POST", "/todos"))
+
+			Expect(routes).To(Equal([]RouteInfo{{}, {}}))
+		})
+	})
+
+	Describe("Route pattern", func() {
+		var (
+			router   Router
+			patterns []string
+		)
+
+		BeforeEach(func() {
+			router = NewRouter(RouterConfig{RedirectTrailingSlash: true})
+			patterns = make([]string, 0)
+			router.Use(func(req Request, res Response, next Handler) Result {
+				r := next(req, res)
+				patterns = append(patterns, req.RoutePattern())
+				return r
+			})
+		})
+
+		It("should expose the pattern of the matched route", func() {
+			router.Get("/", emptyHandler)
+			router.Prefix("/todos").Get("/:id", emptyHandler)
+			router.Get("/static/*filepath", emptyHandler)
+
+			router.Handler()(createRequestCtxFromPath("GET", "/"))
+			router.Handler()(createRequestCtxFromPath("GET", "/todos/1"))
+			router.Handler()(createRequestCtxFromPath("HEAD", "/todos/2"))
+			router.Handler()(createRequestCtxFromPath("GET", "/static/css/app.css"))
+
+			Expect(patterns).To(Equal([]string{"/", "/todos/:id", "/todos/:id", "/static/*filepath"}))
+		})
+
+		It("should expose the sentinel patterns when no route is matched", func() {
+			router.Get("/todos", emptyHandler)
+
+			router.Handler()(createRequestCtxFromPath("GET", "/users"))
+			router.Handler()(createRequestCtxFromPath("POST", "/todos"))
+			router.Handler()(createRequestCtxFromPath("OPTIONS", "/todos"))
+			router.Handler()(createRequestCtxFromPath("GET", "/todos/"))
+
+			Expect(patterns).To(Equal([]string{PatternNotFound, PatternMethodNotAllowed, PatternOptions, PatternRedirect}))
+		})
+
+		It("should expose the pattern of the mounted routes with their prefix", func() {
+			sub := DefaultRouter()
+			sub.Get("/", emptyHandler)
+			sub.Get("/:id", emptyHandler)
+			router.Mount("/api/todos", sub)
+
+			router.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+			router.Handler()(createRequestCtxFromPath("GET", "/api/todos/1"))
+			router.Handler()(createRequestCtxFromPath("GET", "/api/todos/1/comments"))
+
+			Expect(patterns).To(Equal([]string{"/api/todos", "/api/todos/:id", PatternNotFound}))
+		})
+	})
+})
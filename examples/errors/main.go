@@ -20,16 +20,16 @@ var config = hermes.ApplicationConfig{
 }
 
 func router() hermes.Router {
-	router := hermes.NewRouter(hermes.RouterConfig{})
+	router := hermes.NewRouter(hermes.RouterConfig{
+		Validator: hermes.ValidatorFunc(validator_v9.New().Struct),
+	})
 	router.Get("/hello", func(req hermes.Request, res hermes.Response) hermes.Result {
 		return res.Error(ErrNotImplemented)
 	})
 	router.Get("/validation", func(req hermes.Request, res hermes.Response) hermes.Result {
-		validator := validator_v9.New()
 		model := &Model{}
-
-		if err := validator.Struct(model); err != nil {
-			return res.Error(errors.Wrap(err, errors.Validation(), ErrModule))
+		if err := req.Bind(model); err != nil {
+			return res.Error(err)
 		}
 		return res.Data(model)
 	})
@@ -44,5 +44,5 @@ func main() {
 }
 
 type Model struct {
-	Name string `validate:"required"`
+	Name string `query:"name" validate:"required"`
 }
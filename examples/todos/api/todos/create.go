@@ -1,7 +1,6 @@
 package todos
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/lab259/hermes"
@@ -20,7 +19,7 @@ func Create(req hermes.Request, res hermes.Response) hermes.Result {
 		return res.Status(400).Error(errors.ErrDescriptionRequired)
 	}
 
-	todo.ID = fmt.Sprintf("%d", now.Unix())
+	todo.ID = now.Unix()
 	db[todo.ID] = todo
 
 	return res.Status(201).Data(&todo)
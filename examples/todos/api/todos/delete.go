@@ -6,7 +6,10 @@ import (
 )
 
 func Delete(req hermes.Request, res hermes.Response) hermes.Result {
-	id := req.Param("id")
+	id, err := hermes.ParseParams(req).Int64("id")
+	if err != nil {
+		return res.Error(err)
+	}
 	if _, found := db[id]; !found {
 		return res.Status(404).Error(errors.ErrTodoNotFound)
 	}
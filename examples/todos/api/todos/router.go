@@ -0,0 +1,16 @@
+package todos
+
+import "github.com/lab259/hermes"
+
+// Router returns the router of the todos domain, meant to be mounted.
+func Router() hermes.Router {
+	router := hermes.DefaultRouter()
+	router.Get("/", Index)
+	router.Post("/", Create)
+	router.Prefix("/:id").Group(func(r hermes.Routable) {
+		r.Get("/", Show)
+		r.Put("/", Update)
+		r.Delete("/", Delete)
+	})
+	return router
+}
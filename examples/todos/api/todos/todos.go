@@ -1,8 +1,8 @@
 package todos
 
-var db = make(map[string]Todo)
+var db = make(map[int64]Todo)
 
 type Todo struct {
-	ID          string `json:"id"`
+	ID          int64  `json:"id"`
 	Description string `json:"description"`
 }
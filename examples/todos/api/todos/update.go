@@ -6,7 +6,10 @@ import (
 )
 
 func Update(req hermes.Request, res hermes.Response) hermes.Result {
-	id := req.Param("id")
+	id, err := hermes.ParseParams(req).Int64("id")
+	if err != nil {
+		return res.Error(err)
+	}
 	_, found := db[id]
 	if !found {
 		return res.Status(404).Error(errors.ErrTodoNotFound)
@@ -12,6 +12,12 @@ import (
 type FasthttpServiceConfiguration struct {
 	Bind string
 	TLS  *FasthttpServiceConfigurationTLS
+
+	// StreamRequestBody calls the handlers before the bodies larger than the
+	// `Server.MaxRequestBodySize` are received, so they can be read through
+	// `Request.BodyStream`. Multipart forms are then read from the stream by
+	// `Request.MultipartForm`.
+	StreamRequestBody bool
 }
 
 // FasthttpServiceConfigurationTLS keeps the configuration for starting a TLS
@@ -58,9 +64,17 @@ func (service *FasthttpService) Restart() error {
 
 // Start ListenAndServe the server. This method is blocking because it uses
 // the fasthttp.ListenAndServe implementation.
+//
+// Multipart forms are not read by the server before calling the handlers, so
+// `Request.MultipartForm` reads them once, within the limits of the router.
 func (service *FasthttpService) Start() error {
 	service.setRunning(true)
 
+	service.Server.DisablePreParseMultipartForm = true
+	if service.Configuration.StreamRequestBody {
+		service.Server.StreamRequestBody = true
+	}
+
 	if service.Configuration.TLS == nil {
 		return service.Server.ListenAndServe(service.Configuration.Bind)
 	}
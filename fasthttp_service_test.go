@@ -82,6 +82,39 @@ var _ = Describe("Services", func() {
 			done <- true
 		}, 1)
 
+		It("should not pre-parse the multipart forms", func(done Done) {
+			var service FasthttpService
+			service.Configuration.Bind = ":32301" // High port
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(service.Start()).To(BeNil())
+			}()
+			time.Sleep(time.Millisecond * 500)
+			Expect(service.Stop()).To(BeNil())
+
+			Expect(service.Server.StreamRequestBody).To(BeFalse())
+			Expect(service.Server.DisablePreParseMultipartForm).To(BeTrue())
+			done <- true
+		}, 1)
+
+		It("should enable streaming the request bodies", func(done Done) {
+			var service FasthttpService
+			service.Configuration.Bind = ":32301" // High port
+			service.Configuration.StreamRequestBody = true
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(service.Start()).To(BeNil())
+			}()
+			time.Sleep(time.Millisecond * 500)
+			Expect(service.Stop()).To(BeNil())
+
+			Expect(service.Server.StreamRequestBody).To(BeTrue())
+			Expect(service.Server.DisablePreParseMultipartForm).To(BeTrue())
+			done <- true
+		}, 1)
+
 		It("should stop a stopped service", func() {
 			var service FasthttpService
 			Expect(service.Stop()).To(BeNil())
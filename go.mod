@@ -3,6 +3,7 @@ module github.com/lab259/hermes
 go 1.12
 
 require (
+	github.com/fxamacker/cbor/v2 v2.2.0
 	github.com/jamillosantos/macchiato v0.0.0-20171220130318-3be045cc5033
 	github.com/lab259/cors v0.1.0
 	github.com/lab259/errors/v2 v2.2.0
@@ -13,8 +14,9 @@ require (
 	github.com/onsi/gomega v1.5.0
 	github.com/prometheus/common v0.9.1
 	github.com/valyala/bytebufferpool v1.0.0
-	github.com/valyala/fasthttp v1.9.0
-	golang.org/x/crypto v0.0.0-20190424203555-c05e17bb3b2d // indirect
+	github.com/valyala/fasthttp v1.32.0
+	github.com/vmihailenco/msgpack/v4 v4.3.12
 	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 	gopkg.in/go-playground/validator.v9 v9.28.0
+	gopkg.in/yaml.v2 v2.2.4
 )
@@ -0,0 +1,77 @@
+package hermes
+
+import (
+	"bytes"
+	"strings"
+)
+
+// host is a routing tree only used for requests whose `Host` matches its
+// pattern, eg. `admin.example.com` or `:tenant.example.com`.
+type host struct {
+	pattern  string
+	tokens   [][]byte
+	names    []string
+	children map[string]*node
+	radix    map[string]*radixNode
+}
+
+func newHost(pattern string) *host {
+	h := &host{
+		pattern:  pattern,
+		tokens:   bytes.Split([]byte(strings.ToLower(pattern)), []byte{'.'}),
+		children: make(map[string]*node),
+	}
+	for _, token := range h.tokens {
+		if len(token) == 0 {
+			panic("empty token in host '" + pattern + "'")
+		}
+		if token[0] == ':' {
+			h.names = append(h.names, string(token[1:]))
+		}
+	}
+	return h
+}
+
+// Matches checks if the `hostname` (without port) matches the pattern of the
+// host. Each wildcard matches a single label and its value is added to
+// `values` only if the whole hostname matches.
+func (h *host) Matches(hostname []byte, values *tokensDescriptor) bool {
+	n := values.n
+	last := len(h.tokens) - 1
+	for i, token := range h.tokens {
+		var label []byte
+		idx := bytes.IndexByte(hostname, '.')
+		if i < last && idx > -1 {
+			label, hostname = hostname[:idx], hostname[idx+1:]
+		} else if i == last && idx == -1 {
+			label = hostname
+		} else {
+			break
+		}
+
+		if token[0] == ':' {
+			if len(label) == 0 {
+				break
+			}
+			values.m = append(values.m, label)
+			values.n++
+		} else if !bytes.EqualFold(token, label) {
+			break
+		}
+
+		if i == last {
+			return true
+		}
+	}
+	values.n = n
+	values.m = values.m[:n]
+	return false
+}
+
+// hostname removes the port from the `Host` header value.
+func hostname(h []byte) []byte {
+	if i := bytes.LastIndexByte(h, ':'); i > bytes.LastIndexByte(h, ']') {
+		return h[:i]
+	}
+	return h
+}
@@ -0,0 +1,58 @@
+package hermes
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Host", func() {
+		It("should match a static host", func() {
+			values := createPathDescriptor()
+			h := newHost("admin.example.com")
+			Expect(h.Matches([]byte("admin.example.com"), values)).To(BeTrue())
+			Expect(h.Matches([]byte("Admin.Example.COM"), values)).To(BeTrue())
+			Expect(values.n).To(Equal(0))
+		})
+
+		It("should not match a different host", func() {
+			values := createPathDescriptor()
+			h := newHost("admin.example.com")
+			Expect(h.Matches([]byte("api.example.com"), values)).To(BeFalse())
+			Expect(h.Matches([]byte("admin.example.com.br"), values)).To(BeFalse())
+			Expect(h.Matches([]byte("example.com"), values)).To(BeFalse())
+		})
+
+		It("should match a host with wildcards", func() {
+			values := createPathDescriptor()
+			h := newHost(":tenant.:env.example.com")
+			Expect(h.names).To(Equal([]string{"tenant", "env"}))
+			Expect(h.Matches([]byte("acme.staging.example.com"), values)).To(BeTrue())
+			Expect(values.n).To(Equal(2))
+			Expect(values.m).To(Equal([][]byte{[]byte("acme"), []byte("staging")}))
+		})
+
+		It("should discard values when the host does not match", func() {
+			values := createPathDescriptor()
+			h := newHost(":tenant.example.com")
+			Expect(h.Matches([]byte("acme.example.org"), values)).To(BeFalse())
+			Expect(h.Matches([]byte("acme.staging.example.com"), values)).To(BeFalse())
+			Expect(h.Matches([]byte(".example.com"), values)).To(BeFalse())
+			Expect(values.n).To(Equal(0))
+			Expect(values.m).To(BeEmpty())
+		})
+
+		It("should panic due to empty labels", func() {
+			Expect(func() {
+				newHost("admin..example.com")
+			}).To(Panic())
+		})
+
+		It("should remove the port from the hostname", func() {
+			Expect(hostname([]byte("example.com:8080"))).To(Equal([]byte("example.com")))
+			Expect(hostname([]byte("example.com"))).To(Equal([]byte("example.com")))
+			Expect(hostname([]byte("[::1]:8080"))).To(Equal([]byte("[::1]")))
+			Expect(hostname([]byte("[::1]"))).To(Equal([]byte("[::1]")))
+		})
+	})
+})
@@ -1,7 +1,10 @@
 package hermes
 
 import (
+	"bufio"
 	"context"
+	"io"
+	"mime/multipart"
 
 	"github.com/valyala/fasthttp"
 )
@@ -17,7 +20,8 @@ type Request interface {
 	// IsJSON return weather request body is application/json
 	IsJSON() bool
 
-	// WantsJSON return weather request accepts application/json
+	// WantsJSON return weather application/json, or a `+json` media type, is
+	// the one preferred by the `Accept` of the request, wildcards aside.
 	WantsJSON() bool
 
 	// URI returns the raw URI
@@ -33,21 +37,63 @@ type Request interface {
 	// Param grabs route param by name
 	Param(name string) string
 
+	// Route returns the route matched by the request, with its path
+	// pattern, name and metadata. It is empty when no route was matched.
+	Route() RouteInfo
+
+	// RoutePattern returns the path pattern of the route matched by the
+	// request, eg. `/todos/:id`. When no route was matched, it is one of
+	// `PatternNotFound`, `PatternMethodNotAllowed`, `PatternOptions` or
+	// `PatternRedirect`.
+	RoutePattern() string
+
 	// Query grabs input from the query string by name
 	Query(name string) []byte
 
 	// QueryMulti grabs multiple input from the query string by name
 	QueryMulti(name string) [][]byte
 
-	// Data unmarshals request body to dst
+	// Data unmarshals request body to dst, according to its `Content-Type`.
+	// When dst points to a struct, it is validated by the `Validator` of the
+	// router.
 	Data(dst interface{}) error
 
+	// Bind fills the struct pointed by dst with the body, decoded according
+	// to its `Content-Type`, and the values of the fields tagged with
+	// `param`, `query`, `header` or `form`.
+	// Fields that cannot be converted are reported by a `*BindError`. Then,
+	// dst is validated by the `Validator` of the router.
+	Bind(dst interface{}) error
+
+	// BodyStream returns the body of the request as it is received, when
+	// `FasthttpServiceConfiguration.StreamRequestBody` is enabled, without
+	// buffering it. Otherwise, it reads the buffered body. Once it is read,
+	// `Data`, `Bind` and the other methods reading the body cannot be used.
+	BodyStream() io.Reader
+
 	// Post grabs input from the post data by name
 	Post(name string) []byte
 
 	// PostMulti grabs multiple input from the post data by name
 	PostMulti(name string) [][]byte
 
+	// MultipartForm reads the multipart form of the request, within the
+	// limits of the router. Forms exceeding them are reported by
+	// `ErrRequestEntityTooLarge`. Its files are removed after the request.
+	MultipartForm() (*multipart.Form, error)
+
+	// File returns the first file uploaded with the name in the multipart
+	// form. When there is none, the error wraps `ErrMissingFile`.
+	File(name string) (*multipart.FileHeader, error)
+
+	// Files returns the files uploaded with the name in the multipart form.
+	// When there is none, the error wraps `ErrMissingFile`.
+	Files(name string) ([]*multipart.FileHeader, error)
+
+	// SaveFile saves the uploaded file to the path, moving it when it is
+	// stored in a temporary file.
+	SaveFile(header *multipart.FileHeader, path string) error
+
 	// Cookie grabs input from cookies by name
 	Cookie(name string) []byte
 
@@ -82,6 +128,17 @@ type Response interface {
 	// Error sends the default 500 response
 	Error(error, ...interface{}) Result
 
+	// Stream sends the status and headers, then the body written by fn as it
+	// is flushed, without buffering it. fn runs in another goroutine and is
+	// sent after the handler returns, so it must not use the request nor the
+	// response. When it fails, the body is left unfinished and the connection
+	// is closed, so the client can tell it was cut short.
+	Stream(fn func(w *bufio.Writer) error) Result
+
+	// SSE streams Server-Sent Events sent by fn through the `EventStream`.
+	// As `Stream`, fn runs in another goroutine.
+	SSE(fn func(stream EventStream) error) Result
+
 	File(filepath string) Result
 
 	FileDownload(filepath, filename string) Result
@@ -97,6 +154,7 @@ type Response interface {
 type Result interface {
 	Data(data interface{}) Result
 	Error(error) Result
+	Stream(fn func(w *bufio.Writer) error) Result
 	Redirect(uri string, code int) Result
 	File(filepath string) Result
 	FileDownload(filepath, filename string) Result
@@ -113,4 +171,22 @@ type Router interface {
 	Routable
 
 	Handler() fasthttp.RequestHandler
+
+	// Host returns a `Routable` for the routes of the requests whose host
+	// matches the `pattern`, eg. `admin.example.com` or `:tenant.example.com`.
+	Host(pattern string) Routable
+
+	// Mount makes `sub` serve all requests whose path starts with `prefix`,
+	// keeping its own configuration and middlewares.
+	Mount(prefix string, sub Router)
+
+	// URL builds the path of a named route filling its params, which are
+	// informed as key value pairs.
+	URL(name string, params ...interface{}) (string, error)
+
+	// Routes returns all registered routes, in the order they were registered.
+	Routes() []RouteInfo
+
+	// Walk calls `fn` for each registered route, stopping at the first error.
+	Walk(fn func(route RouteInfo) error) error
 }
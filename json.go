@@ -0,0 +1,64 @@
+package hermes
+
+import (
+	"encoding/json"
+	"io"
+	"sync/atomic"
+)
+
+// JSONCodec encodes and decodes the JSON of `Request.Data`, `Request.Bind`
+// and `Response.Data`, including the bodies of the errors. It can be replaced
+// by `SetJSONCodec`, eg. to use jsoniter.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	NewEncoder(w io.Writer) JSONEncoder
+}
+
+// JSONEncoder writes the JSON of values into a stream.
+type JSONEncoder interface {
+	Encode(v interface{}) error
+}
+
+// StdJSONCodec is the `JSONCodec` of the `encoding/json` package, used by
+// default.
+var StdJSONCodec JSONCodec = stdJSONCodec{}
+
+type stdJSONCodec struct{}
+
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (stdJSONCodec) NewEncoder(w io.Writer) JSONEncoder {
+	return json.NewEncoder(w)
+}
+
+// jsonCodecValue keeps the codec set by `SetJSONCodec`, read without locks as
+// it is used by every request. Codecs are wrapped in a `storedJSONCodec`, as
+// the values stored must all be of the same type.
+var jsonCodecValue atomic.Value
+
+type storedJSONCodec struct {
+	JSONCodec
+}
+
+// SetJSONCodec replaces the codec used for JSON, `StdJSONCodec` when nil.
+func SetJSONCodec(codec JSONCodec) {
+	if codec == nil {
+		codec = StdJSONCodec
+	}
+	jsonCodecValue.Store(storedJSONCodec{codec})
+}
+
+// currentJSONCodec returns the codec set by `SetJSONCodec`.
+func currentJSONCodec() JSONCodec {
+	if stored, ok := jsonCodecValue.Load().(storedJSONCodec); ok {
+		return stored.JSONCodec
+	}
+	return StdJSONCodec
+}
@@ -0,0 +1,120 @@
+package hermes
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingJSONCodec delegates to the standard codec, recording its calls.
+type recordingJSONCodec struct {
+	calls []string
+}
+
+func (codec *recordingJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	codec.calls = append(codec.calls, "marshal")
+	return json.Marshal(v)
+}
+
+func (codec *recordingJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	codec.calls = append(codec.calls, "unmarshal")
+	return json.Unmarshal(data, v)
+}
+
+func (codec *recordingJSONCodec) NewEncoder(w io.Writer) JSONEncoder {
+	codec.calls = append(codec.calls, "encode")
+	return json.NewEncoder(w)
+}
+
+// unencodableError adds data to the error response that cannot be encoded.
+type unencodableError struct{}
+
+func (*unencodableError) Error() string {
+	return "unencodable"
+}
+
+func (*unencodableError) AppendData(response errors.ErrorResponse) {
+	response.SetParam("data", &errornousJson{})
+}
+
+var _ = Describe("Hermes", func() {
+	Describe("JSON codec", func() {
+		var codec *recordingJSONCodec
+
+		BeforeEach(func() {
+			codec = &recordingJSONCodec{}
+			SetJSONCodec(codec)
+		})
+
+		AfterEach(func() {
+			SetJSONCodec(nil)
+		})
+
+		It("should use the standard codec by default", func() {
+			SetJSONCodec(nil)
+			Expect(currentJSONCodec()).To(Equal(StdJSONCodec))
+		})
+
+		It("should decode and encode through the codec", func() {
+			router := DefaultRouter()
+			router.Post("/users", func(req Request, res Response) Result {
+				var user decodedUser
+				if err := req.Data(&user); err != nil {
+					return res.Error(err)
+				}
+				return res.Data(&user)
+			})
+
+			ctx := createRequestCtxFromPath("POST", "/users")
+			ctx.Request.Header.SetContentType("application/json")
+			ctx.Request.SetBodyString(`{"name":"Snake Eyes"}`)
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusOK))
+			Expect(codec.calls).To(Equal([]string{"unmarshal", "encode"}))
+		})
+
+		It("should encode the not found and method not allowed bodies through the codec", func() {
+			router := DefaultRouter()
+			router.Get("/users", emptyHandler)
+
+			ctx := createRequestCtxFromPath("GET", "/todos")
+			ctx.Request.Header.Set("Accept", "application/json")
+			router.Handler()(ctx)
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusNotFound))
+
+			ctx = createRequestCtxFromPath("POST", "/users")
+			ctx.Request.Header.Set("Accept", "application/json")
+			router.Handler()(ctx)
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
+
+			Expect(codec.calls).To(Equal([]string{"encode", "encode"}))
+		})
+
+		It("should encode errors through the codec", func() {
+			res := newResponse()
+			res.Error(errForced)
+
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusInternalServerError))
+			Expect(codec.calls).To(Equal([]string{"encode"}))
+		})
+
+		It("should marshal the internal server error when an error cannot be encoded", func() {
+			res := newResponse()
+			res.Error(&unencodableError{})
+
+			Expect(res.result.r.Response.StatusCode()).To(Equal(StatusInternalServerError))
+			Expect(string(res.result.r.Response.Header.ContentType())).To(Equal("application/json; charset=utf-8"))
+			var body map[string]interface{}
+			Expect(json.Unmarshal(res.result.r.Response.Body(), &body)).To(Succeed())
+			Expect(body).To(Equal(map[string]interface{}{
+				"code":    InternalServerErrorCode,
+				"message": InternalServerErrorMessage,
+			}))
+			Expect(codec.calls).To(Equal([]string{"encode", "marshal"}))
+		})
+	})
+})
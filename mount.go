@@ -0,0 +1,127 @@
+package hermes
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// mount is a router serving all the requests whose path starts with a static
+// prefix.
+type mount struct {
+	prefix string
+	tokens [][]byte
+	router *router
+	// handler is the chain of the middlewares of the parent router
+	// followed by `serve`.
+	handler Handler
+}
+
+// Matches checks if the `path` tokens start with the tokens of the prefix.
+func (m *mount) Matches(path *tokensDescriptor) bool {
+	if path.n < len(m.tokens) {
+		return false
+	}
+	for i, token := range m.tokens {
+		if !bytes.Equal(token, path.m[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// serve dispatches the request to the mounted router, matching its path
+// without the prefix.
+func (m *mount) serve(req Request, res Response) Result {
+	path := acquireTokensDescriptor()
+	values := acquireTokensDescriptor()
+	defer releaseTokensDescriptor(path)
+	defer releaseTokensDescriptor(values)
+
+	base := req.(*BaseRequest)
+	base.mountPrefix += m.prefix
+	base.mountTokens += len(m.tokens)
+	if m.router.validator != nil {
+		base.validator = m.router.validator
+	}
+	if m.router.multipart != (multipartLimits{}) {
+		base.multipart = m.router.multipart
+	}
+
+	// The path is split again, without the prefixes of all the routers
+	// mounted so far, as the handlers only receive the request.
+	split(req.Path(), path)
+	sub := tokensDescriptor{
+		m: path.m[base.mountTokens:],
+		n: path.n - base.mountTokens,
+	}
+	var raw []byte
+	if sub.n > 0 {
+		raw = sub.rest(0)
+	}
+	return m.router.serve(base, res.(*BaseResponse), raw, &sub, values)
+}
+
+// url prepends the prefix to a path of the mounted router.
+func (m *mount) url(path string) string {
+	if path == "/" {
+		return m.prefix
+	}
+	return m.prefix + path
+}
+
+// Mount makes `sub` serve all requests whose path starts with `prefix`. The
+// `sub` router keeps its own `NotFound`, `MethodNotAllowed` and middlewares,
+// which run after the middlewares of this router. Its routes are matched
+// against the path without the `prefix`.
+//
+// Only static prefixes are supported and the routes registered on this router
+// have priority over the mounted routers.
+func (r *router) Mount(prefix string, sub Router) {
+	subRouter, ok := sub.(*router)
+	if !ok {
+		panic(fmt.Sprintf("cannot mount '%s': only routers created by NewRouter can be mounted", prefix))
+	}
+
+	tokens := &tokensDescriptor{}
+	split([]byte(prefix), tokens)
+	if tokens.n == 0 {
+		panic("cannot mount on the root path")
+	}
+	for _, token := range tokens.m {
+		if token[0] == ':' || token[0] == '*' {
+			panic(fmt.Sprintf("cannot mount on '%s': prefix must be static", prefix))
+		}
+	}
+
+	m := &mount{
+		prefix: "/" + strings.Trim(prefix, "/"),
+		tokens: tokens.m,
+		router: subRouter,
+	}
+
+	// Longer prefixes have priority
+	i := 0
+	for i < len(r.mounts) && len(r.mounts[i].tokens) >= len(m.tokens) {
+		if r.mounts[i].prefix == m.prefix {
+			panic(fmt.Sprintf("conflict mounting '%s'", prefix))
+		}
+		i++
+	}
+	r.mounts = append(r.mounts, nil)
+	copy(r.mounts[i+1:], r.mounts[i:])
+	r.mounts[i] = m
+
+	if r.built {
+		r.build()
+	}
+}
+
+func (router *router) findMount(path *tokensDescriptor) *mount {
+	for _, m := range router.mounts {
+		if m.Matches(path) {
+			return m
+		}
+	}
+	return nil
+}
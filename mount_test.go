@@ -0,0 +1,180 @@
+package hermes
+
+import (
+	"strings"
+
+	"github.com/lab259/errors/v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Mount", func() {
+		var (
+			calls  []string
+			parent Router
+			sub    Router
+		)
+
+		record := func(name string, params ...string) Handler {
+			return func(req Request, res Response) Result {
+				call := name
+				for _, p := range params {
+					call += ":" + req.Param(p)
+				}
+				calls = append(calls, call)
+				return res.End()
+			}
+		}
+
+		middleware := func(name string) Middleware {
+			return func(req Request, res Response, next Handler) Result {
+				calls = append(calls, name)
+				return next(req, res)
+			}
+		}
+
+		BeforeEach(func() {
+			calls = make([]string, 0)
+			parent = NewRouter(RouterConfig{NotFound: record("parent.notfound")})
+			sub = NewRouter(RouterConfig{
+				NotFound:         record("sub.notfound"),
+				MethodNotAllowed: record("sub.methodnotallowed"),
+			})
+		})
+
+		It("should dispatch to the mounted router", func() {
+			sub.Get("/", record("index"))
+			sub.Get("/:id", record("show", "id"))
+			parent.Mount("/api/todos", sub)
+
+			parent.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/api/todos/1"))
+
+			Expect(calls).To(Equal([]string{"index", "show:1"}))
+		})
+
+		It("should use the not found and method not allowed of the mounted router", func() {
+			sub.Get("/:id", record("show", "id"))
+			parent.Mount("/todos", sub)
+
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos/1/history"))
+			parent.Handler()(createRequestCtxFromPath("POST", "/todos/1"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/users/1"))
+
+			Expect(calls).To(Equal([]string{"sub.notfound", "sub.methodnotallowed", "parent.notfound"}))
+		})
+
+		It("should call the parent middlewares before the mounted router ones", func() {
+			parent.Use(middleware("parent"))
+			sub.Use(middleware("sub"))
+			sub.Get("/", record("index"))
+			parent.Mount("/todos", sub)
+
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos/1"))
+
+			Expect(calls).To(Equal([]string{"parent", "sub", "index", "parent", "sub", "sub.notfound"}))
+		})
+
+		It("should give priority to the parent routes", func() {
+			sub.Get("/:id", record("sub", "id"))
+			parent.Get("/todos/archived", record("parent"))
+			parent.Mount("/todos", sub)
+
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos/archived"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos/1"))
+
+			Expect(calls).To(Equal([]string{"parent", "sub:1"}))
+		})
+
+		It("should give priority to longer prefixes", func() {
+			archived := NewRouter(RouterConfig{})
+			archived.Get("/", record("archived"))
+			sub.Get("/*path", record("sub", "path"))
+			parent.Mount("/todos", sub)
+			parent.Mount("/todos/archived", archived)
+
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos/archived"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/todos/archive"))
+
+			Expect(calls).To(Equal([]string{"archived", "sub:archive"}))
+		})
+
+		It("should dispatch to the routers mounted in mounted routers", func() {
+			nested := NewRouter(RouterConfig{NotFound: record("nested.notfound")})
+			nested.Get("/x", record("x"))
+			nested.Get("/:id", record("nested", "id"))
+			sub.Mount("/b", nested)
+			parent.Mount("/a", sub)
+
+			parent.Handler()(createRequestCtxFromPath("GET", "/a/b/x"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/a/b/1"))
+			parent.Handler()(createRequestCtxFromPath("GET", "/a/b/x/y"))
+
+			Expect(calls).To(Equal([]string{"x", "nested:1", "nested.notfound"}))
+			Expect(parent.Routes()).To(Equal([]RouteInfo{
+				{Method: "GET", Path: "/a/b/x"},
+				{Method: "GET", Path: "/a/b/:id", Params: []string{"id"}},
+			}))
+		})
+
+		It("should list the allowed methods of the mounted router", func() {
+			sub.Get("/:id", emptyHandler)
+			sub.Put("/:id", emptyHandler)
+			parent.Mount("/todos", sub)
+
+			ctx := createRequestCtxFromPath("OPTIONS", "/todos/1")
+			parent.Handler()(ctx)
+
+			Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("GET", "HEAD", "PUT", "OPTIONS"))
+		})
+
+		It("should list and build the URLs of the mounted routes", func() {
+			parent.Get("/health", emptyHandler)
+			sub.Get("/", emptyHandler).Name("todos.index")
+			sub.Get("/:id", emptyHandler).Name("todos.show")
+			parent.Mount("/todos/", sub)
+
+			Expect(parent.Routes()).To(Equal([]RouteInfo{
+				{Method: "GET", Path: "/health"},
+				{Method: "GET", Path: "/todos", Name: "todos.index"},
+				{Method: "GET", Path: "/todos/:id", Name: "todos.show", Params: []string{"id"}},
+			}))
+
+			url, err := parent.URL("todos.index")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/todos"))
+
+			url, err = parent.URL("todos.show", "id", 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal("/todos/1"))
+
+			url, err = parent.URL("todos.show")
+			Expect(errors.Is(err, ErrRouteParamMissing)).To(BeTrue())
+			Expect(url).To(BeEmpty())
+		})
+
+		It("should panic due to invalid prefixes", func() {
+			Expect(func() {
+				parent.Mount("/", sub)
+			}).To(Panic())
+
+			Expect(func() {
+				parent.Mount("/users/:id", sub)
+			}).To(Panic())
+
+			Expect(func() {
+				parent.Mount("/static/*filepath", sub)
+			}).To(Panic())
+		})
+
+		It("should panic due to conflicting prefixes", func() {
+			parent.Mount("/todos", sub)
+			Expect(func() {
+				parent.Mount("/todos/", NewRouter(RouterConfig{}))
+			}).To(Panic())
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package hermes
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/lab259/errors/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultMultipartMaxMemory is the size of the files of multipart forms kept
+// in memory when `RouterConfig.MultipartMaxMemory` is not set.
+const DefaultMultipartMaxMemory = 32 << 20
+
+var (
+	RequestEntityTooLargeErrorCode    = "request-entity-too-large"
+	RequestEntityTooLargeErrorMessage = "The data you sent is larger than we can handle."
+
+	MissingFileErrorCode = "missing-file"
+
+	// ErrRequestEntityTooLarge is returned by `Request.MultipartForm` when
+	// the form exceeds the limits of the router. It is reported as a 413.
+	ErrRequestEntityTooLarge = errors.Wrap(
+		errors.New("request entity too large"),
+		errors.Http(StatusRequestEntityTooLarge),
+		errors.Code(RequestEntityTooLargeErrorCode),
+		errors.Message(RequestEntityTooLargeErrorMessage),
+	)
+
+	// ErrMissingFile is the reason of the error returned by `Request.File`
+	// and `Request.Files` when there is no file with the name. It is reported
+	// as a 400.
+	ErrMissingFile = errors.New("missing file")
+)
+
+// multipartLimits are the limits applied when reading multipart forms.
+type multipartLimits struct {
+	// maxMemory is the size of the files kept in memory, the remaining is
+	// stored in temporary files.
+	maxMemory int64
+	// maxSize is the maximum size of the body, unlimited when zero.
+	maxSize int64
+}
+
+func missingFile(name string) error {
+	return errors.Wrap(
+		ErrMissingFile,
+		errors.Http(StatusBadRequest),
+		errors.Code(MissingFileErrorCode),
+		errors.Message(fmt.Sprintf("The file '%s' is missing.", name)),
+	)
+}
+
+// readMultipartForm reads the multipart form of the request within the
+// `limits`. Streamed bodies are read without buffering them.
+func readMultipartForm(ctx *fasthttp.RequestCtx, limits multipartLimits) (*multipart.Form, error) {
+	r := &ctx.Request
+	boundary := r.Header.MultipartFormBoundary()
+	if len(boundary) == 0 {
+		return nil, fasthttp.ErrNoMultipartForm
+	}
+
+	body := &sizeLimitedReader{max: limits.maxSize}
+	if ce := r.Header.Peek("Content-Encoding"); bytes.Equal(ce, []byte("gzip")) {
+		b, err := r.BodyGunzip()
+		if err != nil {
+			return nil, fmt.Errorf("cannot gunzip request body: %s", err)
+		}
+		body.r = bytes.NewReader(b)
+	} else if len(ce) > 0 {
+		return nil, fmt.Errorf("unsupported Content-Encoding: %q", ce)
+	} else if stream := ctx.RequestBodyStream(); stream != nil {
+		body.r = stream
+	} else {
+		body.r = bytes.NewReader(r.Body())
+	}
+
+	maxMemory := limits.maxMemory
+	if maxMemory <= 0 {
+		maxMemory = DefaultMultipartMaxMemory
+	}
+	form, err := multipart.NewReader(body, string(boundary)).ReadForm(maxMemory)
+	if body.exceeded {
+		if form != nil {
+			form.RemoveAll()
+		}
+		return nil, ErrRequestEntityTooLarge
+	}
+	if err == multipart.ErrMessageTooLarge {
+		return nil, ErrRequestEntityTooLarge
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read multipart/form-data body: %s", err)
+	}
+	return form, nil
+}
+
+// sizeLimitedReader fails reading more than `max` bytes, when it is set.
+type sizeLimitedReader struct {
+	r        io.Reader
+	max      int64
+	read     int64
+	exceeded bool
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.max > 0 && l.read > l.max {
+		l.exceeded = true
+		return n, ErrRequestEntityTooLarge
+	}
+	return n, err
+}
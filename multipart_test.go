@@ -0,0 +1,198 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Multipart forms", func() {
+		// upload returns a multipart form with the files, named by their
+		// contents, and a `name` field.
+		upload := func(field string, contents ...string) (string, []byte) {
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			Expect(w.WriteField("name", "Snake Eyes")).To(Succeed())
+			for _, content := range contents {
+				f, err := w.CreateFormFile(field, content+".txt")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = f.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(w.Close()).To(Succeed())
+			return w.FormDataContentType(), body.Bytes()
+		}
+
+		newUploadRequest := func(contentType string, body []byte) *BaseRequest {
+			req := newRequest()
+			req.Raw().Request.Header.SetMethod("POST")
+			req.Raw().Request.Header.SetContentType(contentType)
+			req.Raw().Request.SetBody(body)
+			return req
+		}
+
+		read := func(header *multipart.FileHeader) string {
+			f, err := header.Open()
+			Expect(err).ToNot(HaveOccurred())
+			defer f.Close()
+			data, err := ioutil.ReadAll(f)
+			Expect(err).ToNot(HaveOccurred())
+			return string(data)
+		}
+
+		It("should read the multipart form", func() {
+			req := newUploadRequest(upload("avatar", "face"))
+			defer ReleaseRequest(req)
+
+			form, err := req.MultipartForm()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(form.Value).To(Equal(map[string][]string{"name": {"Snake Eyes"}}))
+			Expect(form.File).To(HaveKey("avatar"))
+
+			again, err := req.MultipartForm()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again).To(BeIdenticalTo(form))
+		})
+
+		It("should return the first file", func() {
+			req := newUploadRequest(upload("avatar", "face", "mask"))
+			defer ReleaseRequest(req)
+
+			file, err := req.File("avatar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Filename).To(Equal("face.txt"))
+			Expect(read(file)).To(Equal("face"))
+		})
+
+		It("should return all the files", func() {
+			req := newUploadRequest(upload("photos", "face", "mask"))
+			defer ReleaseRequest(req)
+
+			files, err := req.Files("photos")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(HaveLen(2))
+			Expect(read(files[0])).To(Equal("face"))
+			Expect(read(files[1])).To(Equal("mask"))
+		})
+
+		It("should fail when the file is missing", func() {
+			req := newUploadRequest(upload("avatar", "face"))
+			defer ReleaseRequest(req)
+
+			_, err := req.File("photos")
+			Expect(errors.Is(err, ErrMissingFile)).To(BeTrue())
+			_, err = req.Files("photos")
+			Expect(errors.Is(err, ErrMissingFile)).To(BeTrue())
+		})
+
+		It("should fail when the request is not a multipart form", func() {
+			req := newUploadRequest("application/x-www-form-urlencoded", []byte("name=Snake+Eyes"))
+			defer ReleaseRequest(req)
+
+			_, err := req.File("avatar")
+			Expect(err).To(Equal(fasthttp.ErrNoMultipartForm))
+		})
+
+		It("should save the file", func() {
+			dir, err := ioutil.TempDir("", "hermes")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			req := newUploadRequest(upload("avatar", "face"))
+			defer ReleaseRequest(req)
+
+			file, err := req.File("avatar")
+			Expect(err).ToNot(HaveOccurred())
+			path := filepath.Join(dir, file.Filename)
+			Expect(req.SaveFile(file, path)).To(Succeed())
+			Expect(ioutil.ReadFile(path)).To(Equal([]byte("face")))
+		})
+
+		It("should store the files exceeding the max memory in temporary files, removed after the request", func() {
+			req := newUploadRequest(upload("avatar", "a large face"))
+			req.multipart.maxMemory = 1
+
+			file, err := req.File("avatar")
+			Expect(err).ToNot(HaveOccurred())
+			f, err := file.Open()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+			Expect(f).To(BeAssignableToTypeOf(&os.File{}))
+			name := f.(*os.File).Name()
+			Expect(name).To(BeAnExistingFile())
+
+			ReleaseRequest(req)
+			Expect(name).ToNot(BeAnExistingFile())
+		})
+
+		It("should fail when the form exceeds the max size", func() {
+			req := newUploadRequest(upload("avatar", "face"))
+			defer ReleaseRequest(req)
+			req.multipart.maxSize = 10
+
+			_, err := req.File("avatar")
+			Expect(errors.Is(err, ErrRequestEntityTooLarge)).To(BeTrue())
+
+			var user decodedUser
+			Expect(errors.Is(req.Data(&user), ErrRequestEntityTooLarge)).To(BeTrue())
+			Expect(errors.Is(req.Bind(&user), ErrRequestEntityTooLarge)).To(BeTrue())
+		})
+
+		It("should respond a 413 when the form exceeds the max size of the router", func() {
+			router := NewRouter(RouterConfig{MultipartMaxSize: 10})
+			router.Post("/avatar", func(req Request, res Response) Result {
+				if _, err := req.File("avatar"); err != nil {
+					return res.Error(err)
+				}
+				return res.End()
+			})
+
+			ctx := createRequestCtxFromPath("POST", "/avatar")
+			contentType, body := upload("avatar", "face")
+			ctx.Request.Header.SetContentType(contentType)
+			ctx.Request.SetBody(body)
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusRequestEntityTooLarge))
+			var data map[string]interface{}
+			Expect(json.Unmarshal(ctx.Response.Body(), &data)).To(Succeed())
+			Expect(data).To(Equal(map[string]interface{}{
+				"code":    RequestEntityTooLargeErrorCode,
+				"message": RequestEntityTooLargeErrorMessage,
+			}))
+		})
+
+		It("should respond a 400 when the file is missing", func() {
+			router := DefaultRouter()
+			router.Post("/avatar", func(req Request, res Response) Result {
+				if _, err := req.File("avatar"); err != nil {
+					return res.Error(err)
+				}
+				return res.End()
+			})
+
+			ctx := createRequestCtxFromPath("POST", "/avatar")
+			contentType, body := upload("photos", "face")
+			ctx.Request.Header.SetContentType(contentType)
+			ctx.Request.SetBody(body)
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusBadRequest))
+			var data map[string]interface{}
+			Expect(json.Unmarshal(ctx.Response.Body(), &data)).To(Succeed())
+			Expect(data).To(Equal(map[string]interface{}{
+				"code":    MissingFileErrorCode,
+				"message": "The file 'avatar' is missing.",
+			}))
+		})
+	})
+})
@@ -3,13 +3,24 @@ package hermes
 import (
 	"bytes"
 	"fmt"
+	"strings"
 )
 
 type node struct {
-	wildcard *node
-	children map[string]*node
-	handler  Handler
-	names    []string
+	wildcard    *node
+	constrained []*node
+	constraint  *paramConstraint
+	catchAll    *node
+	children    map[string]*node
+	// handle is the handler registered for the route and middlewares the
+	// ones added through its groups. The router builds the chain of the
+	// router middlewares, followed by those, into handler.
+	handle      Handler
+	middlewares []Middleware
+	handler     Handler
+	names       []string
+	// endpoint is the route registered for the node.
+	endpoint *endpoint
 }
 
 func newNode() *node {
@@ -18,7 +29,19 @@ func newNode() *node {
 	}
 }
 
-func (n *node) Add(path string, handler Handler, names []string, middlewares []Middleware) {
+// constrainedChild returns the constrained wildcard child using the `expr`
+// constraint, if any.
+func (n *node) constrainedChild(expr string) *node {
+	for _, child := range n.constrained {
+		if child.constraint.expr == expr {
+			return child
+		}
+	}
+	return nil
+}
+
+// Add adds the `path` to the tree, returning the node that handles it.
+func (n *node) Add(path string, handler Handler, names []string, middlewares []Middleware) *node {
 	// Split path into chunks between `/`
 	pathBytes := bytes.Split([]byte(path), []byte{'/'})
 	lpath := len(pathBytes)
@@ -27,15 +50,39 @@ func (n *node) Add(path string, handler Handler, names []string, middlewares []M
 	for i := 0; i < lpath; i++ {
 		token := pathBytes[i]
 		if len(token) > 0 {
-			// If this token is a wildcard
-			if token[0] == ':' {
-				name := string(token[1:])
-				node := parent.wildcard
+			// If this token is a catch all, it must be the last one
+			if token[0] == '*' {
+				if i+1 < lpath && (i+2 < lpath || len(pathBytes[i+1]) > 0) {
+					panic(fmt.Sprintf("catch all must be the last token in '%s'", path))
+				}
+				if parent.catchAll == nil {
+					parent.catchAll = newNode()
+				} else if parent.catchAll.handler != nil {
+					panic(fmt.Sprintf("conflict adding '%s'", path))
+				}
+				parent.catchAll.names = append(names, string(token[1:]))
+				parent.catchAll.setHandler(handler, middlewares)
+				return parent.catchAll
+			} else if token[0] == ':' {
+				// If this token is a wildcard
+				name, constraint := parseParam(path, token[1:])
+				var node *node
 				nodeCreated := false
-				if node == nil {
-					node = newNode()
-					parent.wildcard = node
-					nodeCreated = true
+				if constraint == nil {
+					node = parent.wildcard
+					if node == nil {
+						node = newNode()
+						parent.wildcard = node
+						nodeCreated = true
+					}
+				} else {
+					node = parent.constrainedChild(constraint.expr)
+					if node == nil {
+						node = newNode()
+						node.constraint = constraint
+						parent.constrained = append(parent.constrained, node)
+						nodeCreated = true
+					}
 				}
 				// If names is not defined yet
 				if names == nil {
@@ -50,7 +97,7 @@ func (n *node) Add(path string, handler Handler, names []string, middlewares []M
 					}
 					// Initialize stuff
 					node.names = names
-					node.handler = newHandler(handler, middlewares)
+					node.setHandler(handler, middlewares)
 				}
 				continue
 			} else {
@@ -71,19 +118,57 @@ func (n *node) Add(path string, handler Handler, names []string, middlewares []M
 						panic(fmt.Sprintf("conflict adding '%s'", path))
 					}
 					node.names = names
-					node.handler = newHandler(handler, middlewares)
-					return
+					node.setHandler(handler, middlewares)
+					return node
 				}
 			}
 		} else if i+1 < lpath {
 			// Cannot deal with empty tokens
 			panic("empty token")
 		} else {
-			// Just set the node info
-			n.names = names
-			n.handler = newHandler(handler, middlewares)
+			// This is the end of the path, ending with `/`
+			if parent.handler != nil {
+				panic(fmt.Sprintf("conflict adding '%s'", path))
+			}
+			parent.names = names
+			parent.setHandler(handler, middlewares)
 		}
 	}
+	return parent
+}
+
+// setHandler sets the handler of the route. Until the router builds its chain,
+// it is called without any middleware.
+func (n *node) setHandler(handler Handler, middlewares []Middleware) {
+	n.handle = handler
+	// Copied, so the groups using the same backing array do not change it
+	n.middlewares = append([]Middleware(nil), middlewares...)
+	n.handler = handler
+}
+
+// build builds the chain of the `middlewares` followed by the middlewares of
+// the route.
+func (n *node) build(middlewares []Middleware) {
+	m := make([]Middleware, 0, len(middlewares)+len(n.middlewares))
+	m = append(append(m, middlewares...), n.middlewares...)
+	n.handler = newHandler(n.handle, m)
+}
+
+// walk calls `fn` for this node and all its descendants.
+func (n *node) walk(fn func(n *node)) {
+	fn(n)
+	for _, child := range n.children {
+		child.walk(fn)
+	}
+	for _, child := range n.constrained {
+		child.walk(fn)
+	}
+	if n.wildcard != nil {
+		n.wildcard.walk(fn)
+	}
+	if n.catchAll != nil {
+		n.catchAll.walk(fn)
+	}
 }
 
 func newHandler(h Handler, m []Middleware) Handler {
@@ -103,33 +188,103 @@ func newHandler(h Handler, m []Middleware) Handler {
 	return sagas[0]
 }
 
+// Matches looks for the node that handles the `path` tokens starting at `s`.
+// Static children have priority over the constrained wildcards (tried in the
+// order they were added), which have priority over the wildcard, which has
+// priority over the catch all. When a branch dead-ends, the next one is
+// tried, so values captured by the failed branch are discarded.
 func (n *node) Matches(s int, path *tokensDescriptor, values *tokensDescriptor) (bool, *node) {
-	for i := s; i < path.n; i++ {
-		token := string(path.m[i])
-		node, ok := n.children[token]
-		if ok {
-			if i+1 < path.n {
-				return node.Matches(i+1, path, values)
-			} else if node.handler == nil {
-				return false, nil
-			} else {
-				return true, node
-			}
-		} else if n.wildcard != nil {
-			if values != nil {
-				values.m = append(values.m, path.m[i])
-				values.n++
-			}
-			if i+1 < path.n {
-				return n.wildcard.Matches(i+1, path, values)
-			} else if n.wildcard.handler == nil {
-				return false, nil
-			} else {
-				return true, n.wildcard
-			}
-		} else {
+	if s >= path.n {
+		if n.handler == nil {
 			return false, nil
 		}
+		return true, n
+	}
+
+	if node, ok := n.children[string(path.m[s])]; ok {
+		if found, node := node.Matches(s+1, path, values); found {
+			return true, node
+		}
+	}
+
+	for _, node := range n.constrained {
+		if !node.constraint.match(path.m[s]) {
+			continue
+		}
+		if values != nil {
+			values.m = append(values.m, path.m[s])
+			values.n++
+		}
+		if found, node := node.Matches(s+1, path, values); found {
+			return true, node
+		}
+		if values != nil {
+			values.n--
+			values.m = values.m[:values.n]
+		}
+	}
+
+	if n.wildcard != nil {
+		if values != nil {
+			values.m = append(values.m, path.m[s])
+			values.n++
+		}
+		if found, node := n.wildcard.Matches(s+1, path, values); found {
+			return true, node
+		}
+		if values != nil {
+			values.n--
+			values.m = values.m[:values.n]
+		}
+	}
+
+	if n.catchAll != nil {
+		// The catch all takes everything left, slashes included
+		if values != nil {
+			values.m = append(values.m, path.rest(s))
+			values.n++
+		}
+		return true, n.catchAll
 	}
+
 	return false, nil
 }
+
+// findCaseInsensitive works like `Matches` but comparing the static tokens
+// case insensitively. The path of the matched route is appended to `dst`.
+func (n *node) findCaseInsensitive(s int, path *tokensDescriptor, dst []byte) ([]byte, bool) {
+	if s >= path.n {
+		return dst, n.handler != nil
+	}
+
+	token := string(path.m[s])
+	for key, node := range n.children {
+		if !strings.EqualFold(key, token) {
+			continue
+		}
+		if fixed, found := node.findCaseInsensitive(s+1, path, append(append(dst, '/'), key...)); found {
+			return fixed, true
+		}
+	}
+
+	for _, node := range n.constrained {
+		if !node.constraint.match(path.m[s]) {
+			continue
+		}
+		if fixed, found := node.findCaseInsensitive(s+1, path, append(append(dst, '/'), token...)); found {
+			return fixed, true
+		}
+	}
+
+	if n.wildcard != nil {
+		if fixed, found := n.wildcard.findCaseInsensitive(s+1, path, append(append(dst, '/'), token...)); found {
+			return fixed, true
+		}
+	}
+
+	if n.catchAll != nil {
+		return append(append(dst, '/'), path.rest(s)...), true
+	}
+
+	return dst, false
+}
@@ -0,0 +1,110 @@
+package hermes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lab259/errors/v2"
+)
+
+var (
+	InvalidParamErrorCode = "invalid-param"
+
+	// ErrParamInvalid is returned by `Params` when a path param is missing or
+	// cannot be parsed. It is reported as a 400.
+	ErrParamInvalid = errors.New("param invalid")
+)
+
+// Params parses the path params of a request into typed values.
+type Params interface {
+	String(string) string
+	Int(string) (int, error)
+	Int64(string) (int64, error)
+	Float(string) (float64, error)
+	Bool(string) (bool, error)
+	// UUID parses the param in the canonical form, eg.
+	// `6ba7b810-9dad-11d1-80b4-00c04fd430c8`.
+	UUID(string) ([16]byte, error)
+	// Time parses the param with the `layout`, eg. `time.RFC3339`.
+	Time(string, string) (time.Time, error)
+}
+
+type params struct {
+	req Request
+}
+
+func ParseParams(req Request) Params {
+	return &params{
+		req: req,
+	}
+}
+
+func invalidParam(name string) error {
+	return errors.Wrap(
+		ErrParamInvalid,
+		errors.Http(StatusBadRequest),
+		errors.Code(InvalidParamErrorCode),
+		errors.Message(fmt.Sprintf("The param '%s' is invalid.", name)),
+	)
+}
+
+func (p *params) String(s string) string {
+	return p.req.Param(s)
+}
+
+func (p *params) Int(s string) (int, error) {
+	i, err := strconv.Atoi(p.req.Param(s))
+	if err != nil {
+		return 0, invalidParam(s)
+	}
+	return i, nil
+}
+
+func (p *params) Int64(s string) (int64, error) {
+	i, err := strconv.ParseInt(p.req.Param(s), 10, 64)
+	if err != nil {
+		return 0, invalidParam(s)
+	}
+	return i, nil
+}
+
+func (p *params) Float(s string) (float64, error) {
+	f, err := strconv.ParseFloat(p.req.Param(s), 64)
+	if err != nil {
+		return 0, invalidParam(s)
+	}
+	return f, nil
+}
+
+func (p *params) Bool(s string) (bool, error) {
+	b, err := strconv.ParseBool(p.req.Param(s))
+	if err != nil {
+		return false, invalidParam(s)
+	}
+	return b, nil
+}
+
+func (p *params) UUID(s string) ([16]byte, error) {
+	var uuid [16]byte
+
+	v := p.req.Param(s)
+	if len(v) != 36 || v[8] != '-' || v[13] != '-' || v[18] != '-' || v[23] != '-' {
+		return uuid, invalidParam(s)
+	}
+	// Groups of 8, 4, 4, 4 and 12 hex digits
+	hexDigits := v[0:8] + v[9:13] + v[14:18] + v[19:23] + v[24:36]
+	if _, err := hex.Decode(uuid[:], []byte(hexDigits)); err != nil {
+		return [16]byte{}, invalidParam(s)
+	}
+	return uuid, nil
+}
+
+func (p *params) Time(s string, layout string) (time.Time, error) {
+	t, err := time.Parse(layout, p.req.Param(s))
+	if err != nil {
+		return time.Time{}, invalidParam(s)
+	}
+	return t, nil
+}
@@ -0,0 +1,127 @@
+package hermes
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Params", func() {
+		newRequestWithParams := func(values ...string) *BaseRequest {
+			req := newRequest()
+			for i := 0; i < len(values); i += 2 {
+				req.validParams = append(req.validParams, values[i])
+				req.params = append(req.params, []byte(values[i+1]))
+			}
+			return req
+		}
+
+		It("should get string values", func() {
+			params := ParseParams(newRequestWithParams("username", "gi.joe"))
+			Expect(params.String("username")).To(Equal("gi.joe"))
+			Expect(params.String("missing")).To(BeEmpty())
+		})
+
+		It("should get int values", func() {
+			params := ParseParams(newRequestWithParams("age", "26", "name", "snake"))
+
+			age, err := params.Int("age")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(age).To(Equal(26))
+
+			_, err = params.Int("name")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+
+			_, err = params.Int("missing")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+		})
+
+		It("should get int64 values", func() {
+			params := ParseParams(newRequestWithParams("duration", "86400000", "name", "snake"))
+
+			duration, err := params.Int64("duration")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(duration).To(Equal(int64(86400000)))
+
+			_, err = params.Int64("name")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+		})
+
+		It("should get float values", func() {
+			params := ParseParams(newRequestWithParams("price", "3.14", "name", "snake"))
+
+			price, err := params.Float("price")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(price).To(Equal(3.14))
+
+			_, err = params.Float("name")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+		})
+
+		It("should get bool values", func() {
+			params := ParseParams(newRequestWithParams("done", "true", "name", "snake"))
+
+			done, err := params.Bool("done")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeTrue())
+
+			_, err = params.Bool("name")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+		})
+
+		It("should get uuid values", func() {
+			params := ParseParams(newRequestWithParams(
+				"id", "6ba7b810-9dad-11d1-80b4-00C04FD430C8",
+				"dashless", "6ba7b8109dad11d180b400c04fd430c8",
+				"invalid", "6ba7b810-9dad-11d1-80b4-00c04fd430cg",
+			))
+
+			id, err := params.UUID("id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(id).To(Equal([16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}))
+
+			_, err = params.UUID("dashless")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+
+			_, err = params.UUID("invalid")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+		})
+
+		It("should get time values", func() {
+			params := ParseParams(newRequestWithParams("day", "2019-10-18", "name", "snake"))
+
+			day, err := params.Time("day", "2006-01-02")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(day).To(Equal(time.Date(2019, 10, 18, 0, 0, 0, 0, time.UTC)))
+
+			_, err = params.Time("name", "2006-01-02")
+			Expect(errors.Is(err, ErrParamInvalid)).To(BeTrue())
+		})
+
+		It("should respond invalid params with a bad request", func() {
+			router := DefaultRouter()
+			router.Get("/todos/:id", func(req Request, res Response) Result {
+				id, err := ParseParams(req).Int("id")
+				if err != nil {
+					return res.Error(err)
+				}
+				return res.Data(id)
+			})
+
+			ctx := createRequestCtxFromPath("GET", "/todos/snake")
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusBadRequest))
+			var body map[string]interface{}
+			Expect(json.Unmarshal(ctx.Response.Body(), &body)).To(Succeed())
+			Expect(body).To(Equal(map[string]interface{}{
+				"code":    InvalidParamErrorCode,
+				"message": "The param 'id' is invalid.",
+			}))
+		})
+	})
+})
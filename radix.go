@@ -0,0 +1,196 @@
+package hermes
+
+import (
+	"bytes"
+	"strings"
+)
+
+// radixNode is a compressed radix tree (a trie whose static edges hold as many
+// chars as possible) used to look up the routes of a method without
+// splitting the path nor allocating. It is only an index: the routes are
+// still added to the `node` tree, which checks conflicts, and its leaves are
+// the nodes of that tree.
+type radixNode struct {
+	prefix  string
+	indices string
+	statics []*radixNode
+	// params are the wildcards starting right after this node, the
+	// constrained ones first in the order they were added.
+	params     []*radixNode
+	constraint *paramConstraint
+	catchAll   *node
+	leaf       *node
+}
+
+type radixPart struct {
+	static     string
+	param      bool
+	catchAll   bool
+	constraint *paramConstraint
+}
+
+// radixParts splits a path (without the leading `/`) into static parts
+// (which keep the `/` separators), wildcards and catch alls.
+func radixParts(path string) []radixPart {
+	parts := make([]radixPart, 0, 4)
+	static := 0
+	for i := 0; i <= len(path); i++ {
+		if i < len(path) && (i > 0 && path[i-1] != '/' || path[i] != ':' && path[i] != '*') {
+			continue
+		}
+		if i > static {
+			parts = append(parts, radixPart{static: path[static:i]})
+		}
+		if i == len(path) {
+			break
+		}
+		end := strings.IndexByte(path[i:], '/')
+		if end == -1 {
+			end = len(path)
+		} else {
+			end += i
+		}
+		if path[i] == '*' {
+			parts = append(parts, radixPart{catchAll: true})
+		} else {
+			_, constraint := parseParam(path, []byte(path[i+1:end]))
+			parts = append(parts, radixPart{param: true, constraint: constraint})
+		}
+		static = end
+		i = end
+	}
+	return parts
+}
+
+// Add indexes the `leaf` under the `path`, without the leading `/`.
+func (n *radixNode) Add(path string, leaf *node) {
+	n.add(radixParts(strings.TrimSuffix(path, "/")), leaf)
+}
+
+func (n *radixNode) add(parts []radixPart, leaf *node) {
+	if len(parts) == 0 {
+		n.leaf = leaf
+		return
+	}
+
+	part := parts[0]
+	switch {
+	case part.catchAll:
+		n.catchAll = leaf
+	case part.param:
+		n.paramChild(part.constraint).add(parts[1:], leaf)
+	default:
+		n.addStatic(part.static, parts[1:], leaf)
+	}
+}
+
+func (n *radixNode) paramChild(constraint *paramConstraint) *radixNode {
+	for _, child := range n.params {
+		if (child.constraint == nil && constraint == nil) ||
+			(child.constraint != nil && constraint != nil && child.constraint.expr == constraint.expr) {
+			return child
+		}
+	}
+
+	child := &radixNode{constraint: constraint}
+	if constraint == nil {
+		// The wildcard is always the last one
+		n.params = append(n.params, child)
+		return child
+	}
+	i := len(n.params)
+	if i > 0 && n.params[i-1].constraint == nil {
+		i--
+	}
+	n.params = append(n.params, nil)
+	copy(n.params[i+1:], n.params[i:])
+	n.params[i] = child
+	return child
+}
+
+func (n *radixNode) addStatic(static string, parts []radixPart, leaf *node) {
+	i := strings.IndexByte(n.indices, static[0])
+	if i == -1 {
+		child := &radixNode{prefix: static}
+		n.indices += static[:1]
+		n.statics = append(n.statics, child)
+		child.add(parts, leaf)
+		return
+	}
+
+	child := n.statics[i]
+	l := 0
+	for l < len(static) && l < len(child.prefix) && static[l] == child.prefix[l] {
+		l++
+	}
+	if l < len(child.prefix) {
+		// Split the child keeping the common prefix
+		split := &radixNode{
+			prefix:  child.prefix[:l],
+			indices: child.prefix[l : l+1],
+			statics: []*radixNode{child},
+		}
+		child.prefix = child.prefix[l:]
+		n.statics[i] = split
+		child = split
+	}
+	if l == len(static) {
+		child.add(parts, leaf)
+	} else {
+		child.addStatic(static[l:], parts, leaf)
+	}
+}
+
+// Match returns the leaf matching the `path`, without the leading and trailing
+// `/`. Static edges have priority over the constrained wildcards, which have
+// priority over the wildcard, which has priority over the catch all. Values
+// captured by branches that dead-end are discarded.
+func (n *radixNode) Match(path []byte, values *tokensDescriptor) *node {
+	if len(path) == 0 {
+		return n.leaf
+	}
+
+	if i := strings.IndexByte(n.indices, path[0]); i > -1 {
+		child := n.statics[i]
+		if len(path) >= len(child.prefix) && string(path[:len(child.prefix)]) == child.prefix {
+			if leaf := child.Match(path[len(child.prefix):], values); leaf != nil {
+				return leaf
+			}
+		}
+	}
+
+	if len(n.params) > 0 {
+		end := bytes.IndexByte(path, '/')
+		if end == -1 {
+			end = len(path)
+		}
+		if end > 0 {
+			for _, child := range n.params {
+				if child.constraint != nil && !child.constraint.match(path[:end]) {
+					continue
+				}
+				if values != nil {
+					values.m = append(values.m, path[:end])
+					values.n++
+				}
+				if leaf := child.Match(path[end:], values); leaf != nil {
+					return leaf
+				}
+				if values != nil {
+					values.n--
+					values.m = values.m[:values.n]
+				}
+			}
+		}
+	}
+
+	if n.catchAll != nil {
+		if values != nil {
+			values.m = append(values.m, path)
+			values.n++
+		}
+		return n.catchAll
+	}
+
+	return nil
+}
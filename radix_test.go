@@ -0,0 +1,247 @@
+package hermes
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Radix", func() {
+		It("should split a path into parts", func() {
+			parts := radixParts("users/:id<int>/posts/*filepath")
+			Expect(parts).To(HaveLen(4))
+			Expect(parts[0].static).To(Equal("users/"))
+			Expect(parts[1].param).To(BeTrue())
+			Expect(parts[1].constraint.expr).To(Equal("int"))
+			Expect(parts[2].static).To(Equal("/posts/"))
+			Expect(parts[3].catchAll).To(BeTrue())
+		})
+
+		It("should split a path starting with a wildcard", func() {
+			parts := radixParts(":account/:id")
+			Expect(parts).To(HaveLen(3))
+			Expect(parts[0].param).To(BeTrue())
+			Expect(parts[0].constraint).To(BeNil())
+			Expect(parts[1].static).To(Equal("/"))
+			Expect(parts[2].param).To(BeTrue())
+		})
+
+		It("should not consider `:` and `*` in the middle of a token", func() {
+			parts := radixParts("files/a:b*c")
+			Expect(parts).To(Equal([]radixPart{{static: "files/a:b*c"}}))
+		})
+
+		It("should compress static edges", func() {
+			root := &radixNode{}
+			root.Add("users/me/settings", newNode())
+			root.Add("users/me/profile", newNode())
+			root.Add("users/:id", newNode())
+
+			Expect(root.statics).To(HaveLen(1))
+			users := root.statics[0]
+			Expect(users.prefix).To(Equal("users/"))
+			Expect(users.params).To(HaveLen(1))
+			Expect(users.statics).To(HaveLen(1))
+			me := users.statics[0]
+			Expect(me.prefix).To(Equal("me/"))
+			Expect(me.indices).To(Equal("sp"))
+			Expect(me.statics[0].prefix).To(Equal("settings"))
+			Expect(me.statics[1].prefix).To(Equal("profile"))
+		})
+
+		It("should keep the wildcard after the constrained ones", func() {
+			root := &radixNode{}
+			root.Add(":any", newNode())
+			root.Add(":id<int>", newNode())
+			root.Add(":uuid<uuid>", newNode())
+
+			Expect(root.params).To(HaveLen(3))
+			Expect(root.params[0].constraint.expr).To(Equal("int"))
+			Expect(root.params[1].constraint.expr).To(Equal("uuid"))
+			Expect(root.params[2].constraint).To(BeNil())
+		})
+
+		It("should match the same routes as the node tree", func() {
+			routes := []string{
+				"",
+				"static",
+				"static/second",
+				"users/me/settings",
+				"users/:id/posts",
+				"users/:id<int>",
+				"users/:name<[a-z-]+>/profile",
+				"files/index",
+				"files/:name",
+				"files/*filepath",
+				":account/:subscription/cancel",
+				":account/:subscription",
+				"a/b/c/d",
+				"a/:x/c/e",
+				":y/b/c/f",
+				"proxy/*rest/",
+			}
+			paths := []string{
+				"/", "/static", "/static/", "/static/second", "/static/third",
+				"/users/me/settings", "/users/me/posts", "/users/me", "/users/42",
+				"/users/snake-eyes/profile", "/users/Snake/profile", "/users/42/posts",
+				"/files/index", "/files/indexer", "/files/in", "/files/docs/readme",
+				"/acc/sub/cancel", "/acc/sub", "/acc", "/acc/sub/history",
+				"/a/b/c/d", "/a/b/c/e", "/a/b/c/f", "/a/b/c/g",
+				"/proxy", "/proxy/a", "/proxy/a/b/",
+			}
+
+			root := newNode()
+			radix := &radixNode{}
+			for _, route := range routes {
+				radix.Add(route, root.Add(route, emptyHandler, nil, nil))
+			}
+
+			for _, p := range paths {
+				path := createPathDescriptor()
+				split([]byte(p), path)
+
+				nodeValues := createPathDescriptor()
+				_, expected := root.Matches(0, path, nodeValues)
+
+				radixValues := createPathDescriptor()
+				var actual *node
+				if path.n == 0 {
+					actual = radix.Match(nil, radixValues)
+				} else {
+					actual = radix.Match(path.rest(0), radixValues)
+				}
+
+				Expect(actual).To(BeIdenticalTo(expected), "path: %s", p)
+				Expect(radixValues.m).To(Equal(nodeValues.m), "path: %s", p)
+				Expect(radixValues.n).To(Equal(nodeValues.n), "path: %s", p)
+			}
+		})
+
+		It("should not allocate when matching", func() {
+			radix := &radixNode{}
+			radix.Add("users/:id<int>/posts/:post", newNode())
+			radix.Add("users/:id/settings", newNode())
+			radix.Add("static/*filepath", newNode())
+			values := createPathDescriptor()
+			post := []byte("users/42/posts/hello-world")
+			settings := []byte("users/me/settings")
+			static := []byte("static/css/app.css")
+
+			allocs := testing.AllocsPerRun(100, func() {
+				radix.Match(post, values)
+				radix.Match(settings, values)
+				radix.Match(static, values)
+				values.n = 0
+				values.m = values.m[:0]
+			})
+			Expect(allocs).To(BeZero())
+		})
+
+		Describe("Router", func() {
+			var (
+				router Router
+				calls  []string
+			)
+
+			record := func(name string, params ...string) Handler {
+				return func(req Request, res Response) Result {
+					call := name
+					for _, p := range params {
+						call += ":" + req.Param(p)
+					}
+					calls = append(calls, call)
+					return res.End()
+				}
+			}
+
+			BeforeEach(func() {
+				calls = make([]string, 0)
+				router = NewRouter(RouterConfig{
+					RadixTree: true,
+					NotFound:  record("notfound"),
+				})
+			})
+
+			It("should resolve routes", func() {
+				router.Get("/", record("index"))
+				router.Prefix("/users").Group(func(r Routable) {
+					r.Get("/me/settings", record("settings"))
+					r.Get("/:id<int>", record("show", "id"))
+					r.Get("/:id/posts", record("posts", "id"))
+				})
+				router.Get("/static/*filepath", record("static", "filepath"))
+
+				for _, path := range []string{"/", "/users/me/settings", "/users/42", "/users/me/posts", "/static/css/app.css", "/users/me"} {
+					router.Handler()(createRequestCtxFromPath("GET", path))
+				}
+
+				Expect(calls).To(Equal([]string{"index", "settings", "show:42", "posts:me", "static:css/app.css", "notfound"}))
+			})
+
+			It("should list the allowed methods", func() {
+				router.Get("/todos/:id", emptyHandler)
+				router.Put("/todos/:id", emptyHandler)
+
+				ctx := createRequestCtxFromPath("POST", "/todos/1")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
+				Expect(calls).To(BeEmpty())
+			})
+
+			It("should resolve paths with trailing slashes", func() {
+				router.Get("/", record("index"))
+				router.Get("/users/:id", record("show", "id"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/users/42/"))
+				router.Handler()(createRequestCtxFromPath("GET", "/"))
+
+				Expect(calls).To(Equal([]string{"show:42", "index"}))
+			})
+
+			It("should answer OPTIONS requests", func() {
+				router.Get("/todos/:id", emptyHandler)
+				router.Delete("/todos/:id", emptyHandler)
+
+				ctx := createRequestCtxFromPath("OPTIONS", "/todos/1")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusOK))
+				Expect(string(ctx.Response.Header.Peek("Allow"))).To(ContainSubstring("DELETE"))
+				Expect(calls).To(BeEmpty())
+			})
+
+			It("should resolve HEAD requests through GET routes", func() {
+				router.Get("/todos/:id", record("show", "id"))
+
+				router.Handler()(createRequestCtxFromPath("HEAD", "/todos/1"))
+
+				Expect(calls).To(Equal([]string{"show:1"}))
+			})
+
+			It("should resolve host routes", func() {
+				router.Host(":tenant.example.com").Get("/todos/:id", record("show", "tenant", "id"))
+
+				ctx := createRequestCtxFromPath("GET", "/todos/1")
+				ctx.Request.URI().SetHost("acme.example.com")
+				router.Handler()(ctx)
+
+				Expect(calls).To(Equal([]string{"show:acme:1"}))
+			})
+
+			It("should resolve mounted routes", func() {
+				sub := NewRouter(RouterConfig{RadixTree: true})
+				sub.Get("/", record("index"))
+				sub.Get("/:id", record("show", "id"))
+				router.Mount("/api/todos", sub)
+
+				router.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+				router.Handler()(createRequestCtxFromPath("GET", "/api/todos/1"))
+
+				Expect(calls).To(Equal([]string{"index", "show:1"}))
+			})
+		})
+	})
+})
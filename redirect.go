@@ -0,0 +1,105 @@
+package hermes
+
+import (
+	"bytes"
+	pathpkg "path"
+	"strings"
+)
+
+var (
+	pathDoubleSlash = []byte("//")
+	pathDotSlash    = []byte("/./")
+	pathDotDotSlash = []byte("/../")
+	pathDot         = []byte("/.")
+	pathDotDot      = []byte("/..")
+)
+
+// isCleanPath checks if the path has no `//`, `.` or `..` elements.
+func isCleanPath(p []byte) bool {
+	return !bytes.Contains(p, pathDoubleSlash) &&
+		!bytes.Contains(p, pathDotSlash) &&
+		!bytes.Contains(p, pathDotDotSlash) &&
+		!bytes.HasSuffix(p, pathDot) &&
+		!bytes.HasSuffix(p, pathDotDot)
+}
+
+// cleanPath removes the `//`, `.` and `..` elements of the path, keeping its
+// trailing slash.
+func cleanPath(p string) string {
+	cleaned := pathpkg.Clean("/" + p)
+	if cleaned != "/" && strings.HasSuffix(p, "/") {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// canonicalPath returns the path the request should be redirected to,
+// according to the `RedirectFixedPath` and `RedirectTrailingSlash` options.
+// `original` is the path as sent by the client.
+func (router *router) canonicalPath(original []byte) (string, bool) {
+	fixPath := router.redirectFixedPath && !isCleanPath(original)
+	trimSlash := router.redirectTrailingSlash && len(original) > 1 && original[len(original)-1] == '/'
+	if !fixPath && !trimSlash {
+		return "", false
+	}
+
+	location := string(original)
+	if fixPath {
+		location = cleanPath(location)
+	}
+	if router.redirectTrailingSlash && len(location) > 1 {
+		location = strings.TrimRight(location, "/")
+		if location == "" {
+			location = "/"
+		}
+	}
+	return location, location != string(original)
+}
+
+// findCaseInsensitivePath looks for a route matching the `path` case
+// insensitively, returning its path.
+func (router *router) findCaseInsensitivePath(children map[string]*node, method string, path *tokensDescriptor) (string, bool) {
+	root, ok := children[method]
+	if !ok && method == "HEAD" && router.autoHead {
+		root, ok = children["GET"]
+	}
+	if !ok {
+		return "", false
+	}
+	fixed, found := root.findCaseInsensitive(0, path, make([]byte, 0, 64))
+	if !found {
+		return "", false
+	}
+	if len(fixed) == 0 {
+		fixed = append(fixed, '/')
+	}
+	return string(fixed), true
+}
+
+// redirect permanently redirects the request to the `location`, keeping the
+// query string. GET and HEAD requests are redirected with a 301, others
+// with a 308 so the method and body are kept.
+func (router *router) redirect(req *BaseRequest, res *BaseResponse, location string) Result {
+	code := StatusPermanentRedirect
+	if method := req.Method(); string(method) == "GET" || string(method) == "HEAD" {
+		code = StatusMovedPermanently
+	}
+	if qs := req.URI().QueryString(); len(qs) > 0 {
+		location += "?" + string(qs)
+	}
+	req.pattern = PatternRedirect
+	req.r.SetUserValue(redirectLocationKey, location)
+	req.r.SetUserValue(redirectCodeKey, code)
+	return router.redirectHandler(req, res)
+}
+
+const (
+	redirectLocationKey = "hermes.redirectLocation"
+	redirectCodeKey     = "hermes.redirectCode"
+)
+
+// redirectHandler redirects to the location set by `router.redirect`.
+func redirectHandler(req Request, res Response) Result {
+	ctx := req.Raw()
+	return res.Redirect(ctx.UserValue(redirectLocationKey).(string), ctx.UserValue(redirectCodeKey).(int))
+}
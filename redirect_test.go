@@ -0,0 +1,146 @@
+package hermes
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Redirect", func() {
+		It("should check clean paths", func() {
+			Expect(isCleanPath([]byte("/todos/1"))).To(BeTrue())
+			Expect(isCleanPath([]byte("/todos/1/"))).To(BeTrue())
+			Expect(isCleanPath([]byte("/todos/.hidden"))).To(BeTrue())
+			Expect(isCleanPath([]byte("/todos//1"))).To(BeFalse())
+			Expect(isCleanPath([]byte("/todos/./1"))).To(BeFalse())
+			Expect(isCleanPath([]byte("/todos/../1"))).To(BeFalse())
+			Expect(isCleanPath([]byte("/todos/."))).To(BeFalse())
+			Expect(isCleanPath([]byte("/todos/.."))).To(BeFalse())
+		})
+
+		It("should clean paths", func() {
+			Expect(cleanPath("/todos//1")).To(Equal("/todos/1"))
+			Expect(cleanPath("/todos/./1/")).To(Equal("/todos/1/"))
+			Expect(cleanPath("/todos/../users/")).To(Equal("/users/"))
+			Expect(cleanPath("/../..")).To(Equal("/"))
+			Expect(cleanPath("todos")).To(Equal("/todos"))
+		})
+
+		It("should not redirect when disabled", func() {
+			router := NewRouter(RouterConfig{}).(*router)
+			_, ok := router.canonicalPath([]byte("/todos//1/"))
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should compute the canonical path", func() {
+			router := NewRouter(RouterConfig{RedirectTrailingSlash: true, RedirectFixedPath: true}).(*router)
+
+			location, ok := router.canonicalPath([]byte("/todos//1/"))
+			Expect(ok).To(BeTrue())
+			Expect(location).To(Equal("/todos/1"))
+
+			_, ok = router.canonicalPath([]byte("/"))
+			Expect(ok).To(BeFalse())
+
+			location, ok = router.canonicalPath([]byte("//"))
+			Expect(ok).To(BeTrue())
+			Expect(location).To(Equal("/"))
+		})
+
+		Describe("Router", func() {
+			var router Router
+
+			BeforeEach(func() {
+				router = NewRouter(RouterConfig{
+					RedirectTrailingSlash:            true,
+					RedirectFixedPath:                true,
+					RedirectFixedPathCaseInsensitive: true,
+				})
+				router.Get("/todos", emptyHandler)
+				router.Post("/todos", emptyHandler)
+				router.Get("/todos/:id", emptyHandler)
+				router.Get("/static/*filepath", emptyHandler)
+			})
+
+			It("should redirect GET requests with a trailing slash", func() {
+				ctx := createRequestCtxFromPath("GET", "/todos/")
+				ctx.Request.URI().SetQueryString("page=2")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMovedPermanently))
+				Expect(string(ctx.Response.Header.Peek("Location"))).To(HaveSuffix("/todos?page=2"))
+			})
+
+			It("should redirect other methods keeping them", func() {
+				ctx := createRequestCtxFromPath("POST", "/todos/")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusPermanentRedirect))
+				Expect(string(ctx.Response.Header.Peek("Location"))).To(HaveSuffix("/todos"))
+			})
+
+			It("should redirect paths not clean", func() {
+				ctx := createRequestCtxFromPath("GET", "/users/..//todos/./1")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMovedPermanently))
+				Expect(string(ctx.Response.Header.Peek("Location"))).To(HaveSuffix("/todos/1"))
+			})
+
+			It("should redirect paths matching case insensitively", func() {
+				ctx := createRequestCtxFromPath("GET", "/TODOS/Abc")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMovedPermanently))
+				Expect(string(ctx.Response.Header.Peek("Location"))).To(HaveSuffix("/todos/Abc"))
+
+				ctx = createRequestCtxFromPath("GET", "/Static/CSS/App.css")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMovedPermanently))
+				Expect(string(ctx.Response.Header.Peek("Location"))).To(HaveSuffix("/static/CSS/App.css"))
+			})
+
+			It("should not redirect canonical paths", func() {
+				value := ""
+				router.Get("/users/:id", func(req Request, res Response) Result {
+					value = req.Param("id")
+					return res.End()
+				})
+
+				ctx := createRequestCtxFromPath("GET", "/users/1")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusOK))
+				Expect(value).To(Equal("1"))
+			})
+
+			It("should not redirect paths not found", func() {
+				ctx := createRequestCtxFromPath("GET", "/users/")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusNotFound))
+			})
+
+			It("should match paths with a trailing slash when disabled", func() {
+				router := DefaultRouter()
+				router.Get("/todos", emptyHandler)
+
+				ctx := createRequestCtxFromPath("GET", "/todos/")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusOK))
+			})
+
+			It("should not redirect paths matching case insensitively when disabled", func() {
+				router := NewRouter(RouterConfig{RedirectFixedPath: true})
+				router.Get("/todos", emptyHandler)
+
+				ctx := createRequestCtxFromPath("GET", "/Todos")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusNotFound))
+			})
+		})
+	})
+})
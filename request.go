@@ -3,7 +3,8 @@ package hermes
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"io"
+	"mime/multipart"
 	"sync"
 
 	"github.com/valyala/fasthttp"
@@ -23,6 +24,18 @@ type BaseRequest struct {
 	r           *fasthttp.RequestCtx
 	validParams []string
 	params      [][]byte
+	route       *endpoint
+	// pattern is the sentinel pattern of requests not matching any route.
+	pattern string
+	// mountPrefix is the prefix of the routers mounted for the request.
+	mountPrefix string
+	// mountTokens is the number of path tokens of the `mountPrefix`.
+	mountTokens int
+	validator   Validator
+	multipart   multipartLimits
+	// form is the multipart form read by `MultipartForm`, its files are
+	// removed when the request is released.
+	form *multipart.Form
 }
 
 func AcquireRequest(ctx context.Context, r *fasthttp.RequestCtx) *BaseRequest {
@@ -42,6 +55,17 @@ func (req *BaseRequest) reset() {
 	req.ctx = nil
 	req.validParams = req.validParams[:0]
 	req.params = req.params[:0]
+	req.route = nil
+	req.pattern = ""
+	req.mountPrefix = ""
+	req.mountTokens = 0
+	req.validator = nil
+	req.multipart = multipartLimits{}
+	if req.form != nil {
+		// The files may have been moved by `SaveFile`
+		req.form.RemoveAll()
+		req.form = nil
+	}
 }
 
 func (req *BaseRequest) Raw() *fasthttp.RequestCtx {
@@ -80,6 +104,33 @@ func (req *BaseRequest) Param(name string) string {
 	return ""
 }
 
+func (req *BaseRequest) Route() RouteInfo {
+	if req.route == nil {
+		return RouteInfo{}
+	}
+	route := req.route.route()
+	route.Path = req.mountPath(route.Path)
+	return route
+}
+
+func (req *BaseRequest) RoutePattern() string {
+	if req.route == nil {
+		return req.pattern
+	}
+	return req.mountPath(req.route.path)
+}
+
+// mountPath prepends the prefix of the mounted routers to the `path`.
+func (req *BaseRequest) mountPath(path string) string {
+	if req.mountPrefix == "" {
+		return path
+	}
+	if path == "/" {
+		return req.mountPrefix
+	}
+	return req.mountPrefix + path
+}
+
 func (req *BaseRequest) Query(name string) []byte {
 	return req.r.QueryArgs().Peek(name)
 }
@@ -89,7 +140,24 @@ func (req *BaseRequest) QueryMulti(name string) [][]byte {
 }
 
 func (req *BaseRequest) Data(dst interface{}) error {
-	return json.Unmarshal(req.r.PostBody(), dst)
+	if err := decode(req, dst); err != nil {
+		return err
+	}
+	return validate(req.validator, dst)
+}
+
+func (req *BaseRequest) Bind(dst interface{}) error {
+	if err := bind(req, dst); err != nil {
+		return err
+	}
+	return validate(req.validator, dst)
+}
+
+func (req *BaseRequest) BodyStream() io.Reader {
+	if stream := req.r.RequestBodyStream(); stream != nil {
+		return stream
+	}
+	return bytes.NewReader(req.r.PostBody())
 }
 
 func (req *BaseRequest) Post(name string) []byte {
@@ -100,6 +168,41 @@ func (req *BaseRequest) PostMulti(name string) [][]byte {
 	return req.r.PostArgs().PeekMulti(name)
 }
 
+func (req *BaseRequest) MultipartForm() (*multipart.Form, error) {
+	if req.form == nil {
+		form, err := readMultipartForm(req.r, req.multipart)
+		if err != nil {
+			return nil, err
+		}
+		req.form = form
+	}
+	return req.form, nil
+}
+
+func (req *BaseRequest) File(name string) (*multipart.FileHeader, error) {
+	files, err := req.Files(name)
+	if err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+func (req *BaseRequest) Files(name string) ([]*multipart.FileHeader, error) {
+	form, err := req.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+	files := form.File[name]
+	if len(files) == 0 {
+		return nil, missingFile(name)
+	}
+	return files, nil
+}
+
+func (req *BaseRequest) SaveFile(header *multipart.FileHeader, path string) error {
+	return fasthttp.SaveMultipartFile(header, path)
+}
+
 func (req *BaseRequest) Cookie(name string) []byte {
 	return req.r.Request.Header.Cookie(name)
 }
@@ -121,8 +224,5 @@ func (req *BaseRequest) IsJSON() bool {
 }
 
 func (req *BaseRequest) WantsJSON() bool {
-	accept := req.r.Request.Header.Peek("Accept")
-	laj := len(applicationJSON)
-	return bytes.Equal(accept, applicationJSON) ||
-		((laj < len(accept)) && bytes.Equal(accept[:laj], applicationJSON))
+	return acceptsJSON(req.r.Request.Header.Peek("Accept"))
 }
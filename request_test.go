@@ -1,14 +1,37 @@
 package hermes
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"io/ioutil"
+	"mime/multipart"
+	"net"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
 )
 
+// serveInmemory serves the router with the server, returning a client
+// connected to it.
+func serveInmemory(server *fasthttp.Server, router Router) (*fasthttp.Client, func()) {
+	ln := fasthttputil.NewInmemoryListener()
+	server.Handler = router.Handler()
+	go server.Serve(ln)
+	client := &fasthttp.Client{
+		Dial: func(addr string) (net.Conn, error) {
+			return ln.Dial()
+		},
+	}
+	return client, func() {
+		ln.Close()
+	}
+}
+
 func newRequest() *BaseRequest {
 	return &BaseRequest{
 		ctx: context.Background(),
@@ -137,6 +160,117 @@ var _ = Describe("Hermes", func() {
 			Expect(string(req.Host())).To(Equal("www.gijoe.io"))
 		})
 
+		It("should read the buffered body as a stream", func() {
+			req := newRequest()
+			req.Raw().Request.SetBodyString("name,age\nSnake Eyes,36\n")
+			body, err := ioutil.ReadAll(req.BodyStream())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("name,age\nSnake Eyes,36\n"))
+		})
+
+		Describe("Body stream", func() {
+			csv := strings.Repeat("Snake Eyes,36\n", 10000)
+
+			importHandler := func(req Request, res Response) Result {
+				lines := 0
+				scanner := bufio.NewScanner(req.BodyStream())
+				for scanner.Scan() {
+					lines++
+				}
+				if err := scanner.Err(); err != nil {
+					return res.Error(err)
+				}
+				return res.Data(lines)
+			}
+
+			It("should stream bodies larger than the max request body size", func() {
+				router := DefaultRouter()
+				router.Post("/import", importHandler)
+				client, stop := serveInmemory(&fasthttp.Server{
+					MaxRequestBodySize: 1024,
+					StreamRequestBody:  true,
+				}, router)
+				defer stop()
+
+				req := fasthttp.AcquireRequest()
+				defer fasthttp.ReleaseRequest(req)
+				res := fasthttp.AcquireResponse()
+				defer fasthttp.ReleaseResponse(res)
+				req.SetRequestURI("http://hermes/import")
+				req.Header.SetMethod("POST")
+				req.SetBodyString(csv)
+				Expect(client.Do(req, res)).To(Succeed())
+
+				Expect(res.StatusCode()).To(Equal(StatusOK))
+				Expect(string(res.Body())).To(Equal("10000"))
+			})
+
+			It("should not serve bodies larger than the max request body size when not streaming", func() {
+				router := DefaultRouter()
+				router.Post("/import", importHandler)
+				client, stop := serveInmemory(&fasthttp.Server{
+					MaxRequestBodySize: 1024,
+				}, router)
+				defer stop()
+
+				req := fasthttp.AcquireRequest()
+				defer fasthttp.ReleaseRequest(req)
+				res := fasthttp.AcquireResponse()
+				defer fasthttp.ReleaseResponse(res)
+				req.SetRequestURI("http://hermes/import")
+				req.Header.SetMethod("POST")
+				req.SetBodyString(csv)
+				Expect(client.Do(req, res)).To(Succeed())
+
+				// fasthttp rejects the request before calling the handler
+				Expect(res.StatusCode()).To(Equal(StatusBadRequest))
+			})
+
+			It("should read streamed multipart forms within the max size", func() {
+				router := NewRouter(RouterConfig{MultipartMaxSize: 64 << 10})
+				router.Post("/import", func(req Request, res Response) Result {
+					file, err := req.File("csv")
+					if err != nil {
+						return res.Error(err)
+					}
+					return res.Data(file.Size)
+				})
+				client, stop := serveInmemory(&fasthttp.Server{
+					MaxRequestBodySize:           1024,
+					StreamRequestBody:            true,
+					DisablePreParseMultipartForm: true,
+				}, router)
+				defer stop()
+
+				upload := func(content string) *fasthttp.Response {
+					var body bytes.Buffer
+					w := multipart.NewWriter(&body)
+					f, err := w.CreateFormFile("csv", "users.csv")
+					Expect(err).ToNot(HaveOccurred())
+					_, err = f.Write([]byte(content))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.Close()).To(Succeed())
+
+					req := fasthttp.AcquireRequest()
+					defer fasthttp.ReleaseRequest(req)
+					res := &fasthttp.Response{}
+					req.SetRequestURI("http://hermes/import")
+					req.Header.SetMethod("POST")
+					req.Header.SetContentType(w.FormDataContentType())
+					req.SetBody(body.Bytes())
+					Expect(client.Do(req, res)).To(Succeed())
+					return res
+				}
+
+				res := upload(csv[:32<<10])
+				Expect(res.StatusCode()).To(Equal(StatusOK))
+				Expect(string(res.Body())).To(Equal("32768"))
+
+				res = upload(csv)
+				Expect(res.StatusCode()).To(Equal(StatusRequestEntityTooLarge))
+			})
+		})
+
 		It("should get URI", func() {
 			req := newRequest()
 			req.Raw().Request.SetRequestURI("http://localhost:5000/v1/api")
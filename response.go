@@ -1,6 +1,8 @@
 package hermes
 
 import (
+	"bufio"
+	"context"
 	"sync"
 
 	"github.com/lab259/errors/v2"
@@ -17,10 +19,13 @@ var responsePool = &sync.Pool{
 
 type BaseResponse struct {
 	result result
+	// request is the request being responded, whose context is used by `SSE`.
+	request *BaseRequest
 }
 
 func (res *BaseResponse) reset() {
 	// result is resetted on .End()
+	res.request = nil
 }
 
 func AcquireResponse(r *fasthttp.RequestCtx) *BaseResponse {
@@ -53,6 +58,32 @@ func (res *BaseResponse) Data(data interface{}) Result {
 	return res.result.Data(data)
 }
 
+func (res *BaseResponse) Stream(fn func(w *bufio.Writer) error) Result {
+	return res.result.Stream(fn)
+}
+
+func (res *BaseResponse) SSE(fn func(stream EventStream) error) Result {
+	parent := context.Background()
+	if res.request != nil && res.request.Context() != nil {
+		parent = res.request.Context()
+	}
+	lastEventID := string(res.result.r.Request.Header.Peek("Last-Event-ID"))
+
+	res.result.setContentType(eventStreamContentType)
+	res.result.r.Response.Header.Set("Cache-Control", "no-cache")
+	return res.result.Stream(func(w *bufio.Writer) error {
+		ctx, cancel := context.WithCancel(parent)
+		stream := &eventStream{
+			w:           w,
+			ctx:         ctx,
+			cancel:      cancel,
+			lastEventID: lastEventID,
+		}
+		defer stream.close()
+		return fn(stream)
+	})
+}
+
 func (res *BaseResponse) Error(err error, options ...interface{}) Result {
 	return res.result.Error(errors.Wrap(err, options...))
 }
@@ -1,7 +1,10 @@
 package hermes
 
 import (
+	"bufio"
 	"bytes"
+	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"github.com/lab259/errors/v2"
@@ -9,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
 )
 
 var errForced = errors.New("forced error")
@@ -242,5 +246,144 @@ var _ = Describe("Hermes", func() {
 			Expect(string(res.result.r.Response.Header.Peek("Content-Type"))).To(Equal("application/pdf"))
 			Expect(string(res.result.r.Response.Header.Peek("Content-Disposition"))).To(Equal("attachment; filename=expected.pdf"))
 		})
+
+		Describe("Stream", func() {
+			get := func(client *fasthttp.Client, path string) (*fasthttp.Response, error) {
+				req := fasthttp.AcquireRequest()
+				defer fasthttp.ReleaseRequest(req)
+				req.SetRequestURI("http://hermes" + path)
+				res := &fasthttp.Response{}
+				return res, client.Do(req, res)
+			}
+
+			It("should stream the body with the status and headers", func() {
+				router := DefaultRouter()
+				router.Get("/export", func(req Request, res Response) Result {
+					return res.Status(StatusAccepted).
+						Header("Content-Type", "text/csv").
+						Stream(func(w *bufio.Writer) error {
+							for i := 0; i < 3; i++ {
+								if _, err := fmt.Fprintf(w, "line %d\n", i); err != nil {
+									return err
+								}
+								if err := w.Flush(); err != nil {
+									return err
+								}
+							}
+							return nil
+						})
+				})
+				client, stop := serveInmemory(&fasthttp.Server{}, router)
+				defer stop()
+
+				res, err := get(client, "/export")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.StatusCode()).To(Equal(StatusAccepted))
+				Expect(string(res.Header.ContentType())).To(Equal("text/csv"))
+				Expect(string(res.Body())).To(Equal("line 0\nline 1\nline 2\n"))
+			})
+
+			It("should send the flushed data before the stream ends", func() {
+				proceed := make(chan bool)
+				router := DefaultRouter()
+				router.Get("/poll", func(req Request, res Response) Result {
+					return res.Stream(func(w *bufio.Writer) error {
+						w.WriteString("first")
+						if err := w.Flush(); err != nil {
+							return err
+						}
+						<-proceed
+						_, err := w.WriteString("second")
+						return err
+					})
+				})
+				ln := fasthttputil.NewInmemoryListener()
+				defer ln.Close()
+				go (&fasthttp.Server{Handler: router.Handler()}).Serve(ln)
+
+				conn, err := ln.Dial()
+				Expect(err).ToNot(HaveOccurred())
+				defer conn.Close()
+				_, err = conn.Write([]byte("GET /poll HTTP/1.1\r\nHost: hermes\r\n\r\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				br := bufio.NewReader(conn)
+				var received string
+				for !strings.Contains(received, "first") {
+					line, err := br.ReadString('\n')
+					Expect(err).ToNot(HaveOccurred())
+					received += line
+				}
+				Expect(received).To(ContainSubstring("Transfer-Encoding: chunked"))
+				Expect(received).ToNot(ContainSubstring("second"))
+
+				close(proceed)
+				for !strings.Contains(received, "second") {
+					line, err := br.ReadString('\n')
+					Expect(err).ToNot(HaveOccurred())
+					received += line
+				}
+			})
+
+			It("should leave the body unfinished when the stream fails", func() {
+				router := DefaultRouter()
+				router.Get("/export", func(req Request, res Response) Result {
+					return res.Status(StatusAccepted).Stream(func(w *bufio.Writer) error {
+						w.WriteString("partial")
+						w.Flush()
+						return errForced
+					})
+				})
+				ln := fasthttputil.NewInmemoryListener()
+				defer ln.Close()
+				go (&fasthttp.Server{Handler: router.Handler()}).Serve(ln)
+
+				conn, err := ln.Dial()
+				Expect(err).ToNot(HaveOccurred())
+				defer conn.Close()
+				_, err = conn.Write([]byte("GET /export HTTP/1.1\r\nHost: hermes\r\n\r\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				received, err := ioutil.ReadAll(conn)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(received)).To(HavePrefix("HTTP/1.1 202 Accepted\r\n"))
+				Expect(string(received)).To(ContainSubstring("Transfer-Encoding: chunked\r\n"))
+				Expect(string(received)).To(HaveSuffix("\r\n\r\n7\r\npartial\r\n"))
+			})
+
+			It("should send the status when the stream fails before writing", func() {
+				router := DefaultRouter()
+				router.Get("/export", func(req Request, res Response) Result {
+					return res.Stream(func(w *bufio.Writer) error {
+						return errForced
+					})
+				})
+				ln := fasthttputil.NewInmemoryListener()
+				defer ln.Close()
+				go (&fasthttp.Server{Handler: router.Handler()}).Serve(ln)
+
+				conn, err := ln.Dial()
+				Expect(err).ToNot(HaveOccurred())
+				defer conn.Close()
+				_, err = conn.Write([]byte("GET /export HTTP/1.1\r\nHost: hermes\r\n\r\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				received, err := ioutil.ReadAll(conn)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(received)).To(HavePrefix("HTTP/1.1 200 OK\r\n"))
+				Expect(string(received)).To(HaveSuffix("\r\n\r\n"))
+				Expect(string(received)).ToNot(ContainSubstring("0\r\n\r\n"))
+			})
+
+			It("should not send data after streaming", func() {
+				res := newResponse()
+				res.Stream(func(w *bufio.Writer) error {
+					return nil
+				}).Data("ignored")
+
+				Expect(res.result.r.Response.IsBodyStream()).To(BeTrue())
+				Expect(res.result.r.Response.StatusCode()).To(Equal(StatusOK))
+			})
+		})
 	})
 })
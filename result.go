@@ -1,7 +1,7 @@
 package hermes
 
 import (
-	"encoding/json"
+	"bufio"
 	"fmt"
 	"io"
 	"reflect"
@@ -17,6 +17,9 @@ type result struct {
 
 	status      int
 	hasSentData bool
+	// errored is set while sending an error, which falls back to JSON when
+	// it cannot be negotiated.
+	errored bool
 }
 
 func (r *result) Data(data interface{}) Result {
@@ -40,9 +43,7 @@ func (r *result) Data(data interface{}) Result {
 
 		switch dataType.Kind() {
 		case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
-			r.setContentType(defaultJSONContentType)
-			e := json.NewEncoder(r.r.Response.BodyWriter())
-			if err := e.Encode(data); err != nil {
+			if err := r.encode(data); err != nil {
 				return r.Error(err)
 			}
 		default:
@@ -56,10 +57,49 @@ func (r *result) Data(data interface{}) Result {
 	return r
 }
 
+// encode encodes the `data` with the best encoder negotiated through the
+// `Accept` header of the request that is able to encode it. When none is,
+// `ErrNotAcceptable` is returned, while errors fall back to JSON.
+func (r *result) encode(data interface{}) error {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	for _, entry := range negotiate(r.r.Request.Header.Peek("Accept")) {
+		buff.Reset()
+		if err := entry.encoder.Encode(buff, data); err != nil {
+			if entry == jsonEncoder {
+				// JSON failing is not a matter of negotiation
+				return err
+			}
+			// Not all encoders support all values, eg. XML and maps
+			continue
+		}
+		r.setContentType(entry.contentType)
+		r.r.Response.AppendBody(buff.B)
+		return nil
+	}
+
+	if !r.errored {
+		return ErrNotAcceptable
+	}
+	buff.Reset()
+	if err := jsonEncoder.encoder.Encode(buff, data); err != nil {
+		return err
+	}
+	r.setContentType(jsonEncoder.contentType)
+	r.r.Response.AppendBody(buff.B)
+	return nil
+}
+
 func (r *result) Error(err error) Result {
-	if err == nil {
+	if err == nil || r.hasSentData {
+		return r
+	}
+	if r.errored {
+		r.sendEncodingError()
 		return r
 	}
+	r.errored = true
 
 	errResponse := acquireErrorResponse(StatusInternalServerError)
 	if !errors.AggregateToResponse(err, errResponse) {
@@ -73,6 +113,23 @@ func (r *result) Error(err error) Result {
 	return r
 }
 
+// sendEncodingError sends a 500 when the error being sent could not be
+// encoded.
+func (r *result) sendEncodingError() {
+	r.hasSentData = true
+	body, err := currentJSONCodec().Marshal(map[string]interface{}{
+		"code":    InternalServerErrorCode,
+		"message": InternalServerErrorMessage,
+	})
+	if err != nil {
+		r.r.Error(InternalServerErrorMessage, StatusInternalServerError)
+		return
+	}
+	r.setContentType(defaultJSONContentType)
+	r.r.Response.SetBody(body)
+	r.r.SetStatusCode(StatusInternalServerError)
+}
+
 func (r *result) setStatus() {
 	if r.status == 0 {
 		r.status = StatusOK
@@ -91,6 +148,30 @@ func (r *result) defaultStatus(code int) {
 	}
 }
 
+func (r *result) Stream(fn func(w *bufio.Writer) error) Result {
+	if r.hasSentData {
+		return r
+	}
+
+	r.setStatus()
+	r.hasSentData = true
+	// The writer runs in another goroutine, outliving the request and the
+	// response, so they are not captured. Its error makes fasthttp stop
+	// sending the body before finishing it, closing the connection.
+	pr, pw := io.Pipe()
+	go func() {
+		w := bufio.NewWriter(pw)
+		err := fn(w)
+		if err == nil {
+			err = w.Flush()
+		}
+		pw.CloseWithError(err)
+	}()
+	r.r.Response.ImmediateHeaderFlush = true
+	r.r.SetBodyStream(pr, -1)
+	return r
+}
+
 func (r *result) Redirect(uri string, code int) Result {
 	r.r.Redirect(uri, code)
 	return r
@@ -115,4 +196,5 @@ func (r *result) End() {
 	r.r = nil
 	r.status = 0
 	r.hasSentData = false
+	r.errored = false
 }
@@ -1,13 +1,22 @@
 package hermes
 
+// Routable registers routes. The middlewares informed when registering a
+// route are applied only to it, after the ones of its groups.
 type Routable interface {
-	Delete(path string, handler Handler)
-	Get(path string, handler Handler)
-	Head(path string, handler Handler)
-	Options(path string, handler Handler)
-	Patch(path string, handler Handler)
-	Post(path string, handler Handler)
-	Put(path string, handler Handler)
+	Delete(path string, handler Handler, middlewares ...Middleware) Route
+	Get(path string, handler Handler, middlewares ...Middleware) Route
+	Head(path string, handler Handler, middlewares ...Middleware) Route
+	Options(path string, handler Handler, middlewares ...Middleware) Route
+	Patch(path string, handler Handler, middlewares ...Middleware) Route
+	Post(path string, handler Handler, middlewares ...Middleware) Route
+	Put(path string, handler Handler, middlewares ...Middleware) Route
+
+	// Handle registers the handler for a custom method, eg. `PROPFIND`.
+	Handle(method, path string, handler Handler, middlewares ...Middleware) Route
+	// Any registers the handler for all standard methods.
+	Any(path string, handler Handler, middlewares ...Middleware) Route
+	// Match registers the handler for each of the methods.
+	Match(methods []string, path string, handler Handler, middlewares ...Middleware) Route
 
 	Prefix(path string) Routable
 	Group(func(Routable))
@@ -15,3 +24,12 @@ type Routable interface {
 	Use(...Middleware)
 	With(...Middleware) Routable
 }
+
+// Route is a registered endpoint that can be further described.
+type Route interface {
+	// Name names the route so its URL can be built through `Router.URL`.
+	Name(name string) Route
+	// Meta sets metadata of the route, eg. the scope it requires, which can
+	// be read by middlewares through `Request.Route`.
+	Meta(key string, value interface{}) Route
+}
@@ -5,6 +5,7 @@ import "fmt"
 type route struct {
 	prefix      string
 	router      *router
+	host        *host
 	middlewares []Middleware
 }
 
@@ -21,51 +22,121 @@ func (r *route) path(subpath string) string {
 	return fmt.Sprintf("%s/%s", r.prefix, subpath)
 }
 
-func (r *route) handle(method, path string, handler Handler) {
+func (r *route) handle(method, path string, handler Handler, routeMiddlewares []Middleware) Route {
 	if path[0] != '/' {
 		panic("path must begin with '/' in path '" + path + "'")
 	}
-	root, ok := r.router.children[method]
+	children, radix := r.router.children, r.router.radix
+	var names []string
+	if r.host != nil {
+		children, radix = r.host.children, r.host.radix
+		// Host params come before the path params
+		names = append(names, r.host.names...)
+	}
+	root, ok := children[method]
 	if !ok {
 		root = newNode()
-		r.router.children[method] = root
+		children[method] = root
+	}
+	fullPath := r.path(path)
+	middlewares := r.routeMiddlewares()
+	if len(routeMiddlewares) > 0 {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], routeMiddlewares...)
+	}
+	node := root.Add(fullPath, handler, names, middlewares)
+	if r.router.built {
+		node.build(r.router.middlewares)
+	}
+	if radix != nil {
+		radixRoot, ok := radix[method]
+		if !ok {
+			radixRoot = &radixNode{}
+			radix[method] = radixRoot
+		}
+		radixRoot.Add(fullPath, node)
+	}
+	e := &endpoint{
+		router:      r.router,
+		method:      method,
+		path:        "/" + fullPath,
+		params:      node.names,
+		middlewares: len(middlewares),
+	}
+	if r.host != nil {
+		e.host = r.host.pattern
 	}
-	root.Add(r.path(path), handler, nil, r.middlewares)
+	node.endpoint = e
+	r.router.endpoints = append(r.router.endpoints, e)
+	return e
 }
 
-func (r *route) Delete(path string, handler Handler) {
-	r.handle("DELETE", path, handler)
+func (r *route) Delete(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("DELETE", path, handler, middlewares)
 }
 
-func (r *route) Get(path string, handler Handler) {
-	r.handle("GET", path, handler)
+func (r *route) Get(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("GET", path, handler, middlewares)
 }
 
-func (r *route) Post(path string, handler Handler) {
-	r.handle("POST", path, handler)
+func (r *route) Post(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("POST", path, handler, middlewares)
 }
 
-func (r *route) Put(path string, handler Handler) {
-	r.handle("PUT", path, handler)
+func (r *route) Put(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("PUT", path, handler, middlewares)
 }
 
-func (r *route) Head(path string, handler Handler) {
-	r.handle("HEAD", path, handler)
+func (r *route) Head(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("HEAD", path, handler, middlewares)
 }
 
-func (r *route) Options(path string, handler Handler) {
-	r.handle("OPTIONS", path, handler)
+func (r *route) Options(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("OPTIONS", path, handler, middlewares)
 }
 
-func (r *route) Patch(path string, handler Handler) {
-	r.handle("PATCH", path, handler)
+func (r *route) Patch(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.handle("PATCH", path, handler, middlewares)
+}
+
+func (r *route) Handle(method, path string, handler Handler, middlewares ...Middleware) Route {
+	if method == "" {
+		panic("method must not be empty in path '" + path + "'")
+	}
+	return r.handle(method, path, handler, middlewares)
+}
+
+// anyMethods are the methods registered by `Any`.
+var anyMethods = []string{"CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"}
+
+func (r *route) Any(path string, handler Handler, middlewares ...Middleware) Route {
+	return r.Match(anyMethods, path, handler, middlewares...)
+}
+
+func (r *route) Match(methods []string, path string, handler Handler, middlewares ...Middleware) Route {
+	routes := make(endpoints, len(methods))
+	for i, method := range methods {
+		routes[i] = r.Handle(method, path, handler, middlewares...).(*endpoint)
+	}
+	return routes
+}
+
+// routeMiddlewares returns the middlewares applied only to the routes
+// registered through `r`. The middlewares of the router are applied to all
+// routes when their handlers are built.
+func (r *route) routeMiddlewares() []Middleware {
+	if r == &r.router.route {
+		return nil
+	}
+	return r.middlewares
 }
 
 func (r *route) Prefix(path string) Routable {
+	current := r.routeMiddlewares()
 	return &route{
 		prefix:      r.path(path),
 		router:      r.router,
-		middlewares: r.middlewares,
+		host:        r.host,
+		middlewares: current[:len(current):len(current)],
 	}
 }
 
@@ -78,9 +149,11 @@ func (r *route) Use(middlewares ...Middleware) {
 }
 
 func (r *route) With(middlewares ...Middleware) Routable {
+	current := r.routeMiddlewares()
 	return &route{
 		prefix:      r.prefix,
 		router:      r.router,
-		middlewares: append(r.middlewares, middlewares...),
+		host:        r.host,
+		middlewares: append(current[:len(current):len(current)], middlewares...),
 	}
 }
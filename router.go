@@ -3,22 +3,85 @@ package hermes
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/lab259/errors/v2"
 	"github.com/valyala/fasthttp"
 )
 
 type RouterConfig struct {
 	NotFound         Handler
 	MethodNotAllowed Handler
+
+	// DisableAutoHead disables serving HEAD requests through the GET
+	// handlers, when there is no HEAD handler registered for the path.
+	DisableAutoHead bool
+
+	// RedirectTrailingSlash redirects requests whose path ends with `/` to
+	// the same path without it, eg. `/todos/` to `/todos`.
+	RedirectTrailingSlash bool
+
+	// RedirectFixedPath redirects requests whose path is not clean (with
+	// `//`, `.` or `..` elements) to the cleaned path, eg. `/todos//../users`
+	// to `/users`.
+	RedirectFixedPath bool
+
+	// RadixTree makes the router look up the routes using a compressed
+	// radix tree, which matches the path without splitting it. It assumes
+	// the paths are normalized (see `fasthttp.URI.DisablePathNormalizing`).
+	RadixTree bool
+
+	// RedirectFixedPathCaseInsensitive makes the `RedirectFixedPath` also
+	// redirect paths not found to a route matching them case insensitively,
+	// eg. `/TODOS` to `/todos`.
+	RedirectFixedPathCaseInsensitive bool
+
+	// Validator validates the structs read by `Request.Data` and
+	// `Request.Bind`. Failures are reported by a `*ValidationError`.
+	Validator Validator
+
+	// MultipartMaxMemory is the size of the files of multipart forms kept
+	// in memory, the remaining is stored in temporary files.
+	// `DefaultMultipartMaxMemory` when not set.
+	MultipartMaxMemory int64
+
+	// MultipartMaxSize limits the size of the multipart forms read by
+	// `Request.MultipartForm`, larger forms are reported as a 413.
+	//
+	// Both limits require the server not to read the forms beforehand, as
+	// the `FasthttpService` does, through
+	// `fasthttp.Server.DisablePreParseMultipartForm`.
+	MultipartMaxSize int64
 }
 
 type router struct {
 	route
 	children         map[string]*node
+	radix            map[string]*radixNode
+	names            map[string]*endpoint
+	endpoints        []*endpoint
+	hosts            []*host
+	mounts           []*mount
 	notFound         Handler
 	methodNotAllowed Handler
 	defaultOptions   Handler
+	autoHead         bool
+	validator        Validator
+	multipart        multipartLimits
+
+	// built tells if the handlers were built, they are rebuilt by the changes
+	// made afterwards.
+	built                   bool
+	notFoundHandler         Handler
+	methodNotAllowedHandler Handler
+	optionsHandler          Handler
+	redirectHandler         Handler
+
+	redirectTrailingSlash            bool
+	redirectFixedPath                bool
+	redirectFixedPathCaseInsensitive bool
 }
 
 func DefaultRouter() Router {
@@ -28,8 +91,19 @@ func DefaultRouter() Router {
 func NewRouter(config RouterConfig) Router {
 	r := &router{
 		children:         make(map[string]*node),
+		names:            make(map[string]*endpoint),
 		notFound:         config.NotFound,
 		methodNotAllowed: config.MethodNotAllowed,
+		autoHead:         !config.DisableAutoHead,
+		validator:        config.Validator,
+		multipart: multipartLimits{
+			maxMemory: config.MultipartMaxMemory,
+			maxSize:   config.MultipartMaxSize,
+		},
+
+		redirectTrailingSlash:            config.RedirectTrailingSlash,
+		redirectFixedPath:                config.RedirectFixedPath,
+		redirectFixedPathCaseInsensitive: config.RedirectFixedPath && config.RedirectFixedPathCaseInsensitive,
 	}
 
 	if config.NotFound == nil {
@@ -62,6 +136,10 @@ func NewRouter(config RouterConfig) Router {
 		}
 	}
 
+	if config.RadixTree {
+		r.radix = make(map[string]*radixNode)
+	}
+
 	r.defaultOptions = func(req Request, res Response) Result {
 		return res.End()
 	}
@@ -86,6 +164,14 @@ var tokensDescriptorPool = sync.Pool{
 	},
 }
 
+// rest returns the source bytes from the token `i` up to the end of the last
+// token. It relies on all tokens being slices of the same source (as `split`
+// does), so the original separators are kept and nothing is allocated.
+func (path *tokensDescriptor) rest(i int) []byte {
+	last := path.m[path.n-1]
+	return path.m[i][:cap(path.m[i])-cap(last)+len(last)]
+}
+
 func acquireTokensDescriptor() *tokensDescriptor {
 	return tokensDescriptorPool.Get().(*tokensDescriptor)
 }
@@ -107,10 +193,8 @@ func split(source []byte, dest *tokensDescriptor) {
 			}
 			s = i + 1
 		} else if i+1 == lSource {
-			if i != s {
-				dest.m = append(dest.m, source[s:i+1])
-				dest.n++
-			}
+			dest.m = append(dest.m, source[s:])
+			dest.n++
 		}
 	}
 }
@@ -124,6 +208,33 @@ func (router *router) findHandler(root *node, path *tokensDescriptor, values *to
 	return root.Matches(0, path, values)
 }
 
+// tokenize splits the `raw` path into `path`, unless it was already split.
+func tokenize(raw []byte, path *tokensDescriptor) *tokensDescriptor {
+	if path.n == 0 {
+		split(raw, path)
+	}
+	return path
+}
+
+// match returns the node handling the path for the `method`, or nil. When
+// `radix` is informed, it matches the `raw` path without splitting it,
+// instead of `children`.
+func (router *router) match(children map[string]*node, radix map[string]*radixNode, method string, raw []byte, path *tokensDescriptor, values *tokensDescriptor) *node {
+	if radix != nil {
+		root, ok := radix[method]
+		if !ok {
+			return nil
+		}
+		return root.Match(bytes.Trim(raw, "/"), values)
+	}
+	if root, ok := children[method]; ok {
+		if found, node := router.findHandler(root, tokenize(raw, path), values); found {
+			return node
+		}
+	}
+	return nil
+}
+
 func (router *router) releaseResources(req *BaseRequest, res *BaseResponse, path *tokensDescriptor, values *tokensDescriptor) {
 	ReleaseRequest(req)
 	ReleaseResponse(res)
@@ -131,61 +242,231 @@ func (router *router) releaseResources(req *BaseRequest, res *BaseResponse, path
 	releaseTokensDescriptor(values)
 }
 
+// build builds the chains of middlewares of all the handlers of the router and
+// of the mounted routers, so they are not built for each request.
+func (router *router) build() {
+	router.built = true
+
+	build := func(n *node) {
+		if n.handle != nil {
+			n.build(router.middlewares)
+		}
+	}
+	for _, root := range router.children {
+		root.walk(build)
+	}
+	for _, h := range router.hosts {
+		for _, root := range h.children {
+			root.walk(build)
+		}
+	}
+
+	router.notFoundHandler = newHandler(router.notFound, router.middlewares)
+	router.methodNotAllowedHandler = newHandler(router.methodNotAllowed, router.middlewares)
+	router.optionsHandler = newHandler(router.defaultOptions, router.middlewares)
+	router.redirectHandler = newHandler(redirectHandler, router.middlewares)
+
+	for _, m := range router.mounts {
+		m.router.build()
+		m.handler = newHandler(m.serve, router.middlewares)
+	}
+}
+
+// Handler builds the handlers of the router, returning the
+// `fasthttp.RequestHandler` serving its routes. The routes, middlewares and
+// mounts changed afterwards are applied, but not safely while serving.
 func (router *router) Handler() fasthttp.RequestHandler {
+	router.build()
 	return func(fCtx *fasthttp.RequestCtx) {
 		req := AcquireRequest(context.Background(), fCtx)
 		res := AcquireResponse(fCtx)
+		res.request = req
 		values := acquireTokensDescriptor()
 		path := acquireTokensDescriptor()
 		defer router.releaseResources(req, res, path, values)
 
-		// split request path into tokenDescriptor
-		split(req.Path(), path)
+		req.validator = router.validator
+		req.multipart = router.multipart
 
-		method := string(req.Method())
-		if root, ok := router.children[method]; ok {
-			if found, node := router.findHandler(root, path, values); found {
-				req.params = values.m[:]
-				req.validParams = node.names[:]
-				node.handler(req, res).End()
-				return
-			}
+		// The path is split into the tokens when they are needed, the radix
+		// tree matches it as is
+		router.serve(req, res, req.Path(), path, values).End()
+	}
+}
+
+// serve dispatches the request to the handler matching the `raw` path, whose
+// tokens are split into `path` when needed.
+func (router *router) serve(req *BaseRequest, res *BaseResponse, raw []byte, path *tokensDescriptor, values *tokensDescriptor) Result {
+	children, radix := router.children, router.radix
+	if len(router.hosts) > 0 {
+		if h := router.findHost(hostname(req.Host()), values); h != nil {
+			children, radix = h.children, h.radix
 		}
+	}
+
+	method := string(req.Method())
+	node := router.match(children, radix, method, raw, path, values)
+	if node == nil && method == "HEAD" && router.autoHead {
+		// Serve through the GET handler, the body is kept so the
+		// Content-Length is still computed, but it is not sent.
+		if node = router.match(children, radix, "GET", raw, path, values); node != nil {
+			req.r.Response.SkipBody = true
+		}
+	}
+	if node != nil {
+		if location, ok := router.canonicalPath(req.URI().PathOriginal()); ok {
+			return router.redirect(req, res, location)
+		}
+		req.params = values.m[:]
+		req.validParams = node.names[:]
+		req.route = node.endpoint
+		return node.handler(req, res)
+	}
 
-		if method == "OPTIONS" {
-			// handle OPTIONS requests
-			if allow := router.allowed(req.Path(), method, path); len(allow) > 0 {
-				res.Header("Allow", allow)
-				router.callHandler(req, res, router.middlewares, router.defaultOptions).End()
-				return
+	tokenize(raw, path)
+	if router.redirectFixedPathCaseInsensitive {
+		if location, ok := router.findCaseInsensitivePath(children, method, path); ok {
+			return router.redirect(req, res, location)
+		}
+	}
+
+	if m := router.findMount(path); m != nil {
+		return m.handler(req, res)
+	}
+
+	if method == "OPTIONS" {
+		// handle OPTIONS requests
+		if allow := router.allowed(children, req.Path(), method, path); len(allow) > 0 {
+			res.Header("Allow", allow)
+			req.pattern = PatternOptions
+			return router.optionsHandler(req, res)
+		}
+	} else {
+		// handle 405
+		if allow := router.allowed(children, req.Path(), method, path); len(allow) > 0 {
+			res.Header("Allow", allow)
+			req.pattern = PatternMethodNotAllowed
+			return router.methodNotAllowedHandler(req, res)
+		}
+	}
+
+	req.pattern = PatternNotFound
+	return router.notFoundHandler(req, res)
+}
+
+// Host returns a `Routable` whose routes are only matched by requests with a
+// `Host` matching the `pattern`, eg. `admin.example.com`. Labels starting
+// with `:` are wildcards, eg. `:tenant.example.com`, and can be read through
+// `Request.Param`.
+//
+// Requests matching a host are routed only through the routes of that host.
+func (router *router) Host(pattern string) Routable {
+	var h *host
+	for _, current := range router.hosts {
+		if strings.EqualFold(current.pattern, pattern) {
+			h = current
+			break
+		}
+	}
+	if h == nil {
+		h = newHost(pattern)
+		if router.radix != nil {
+			h.radix = make(map[string]*radixNode)
+		}
+		if len(h.names) == 0 {
+			// Hosts without wildcards have priority
+			i := 0
+			for i < len(router.hosts) && len(router.hosts[i].names) == 0 {
+				i++
 			}
+			router.hosts = append(router.hosts, nil)
+			copy(router.hosts[i+1:], router.hosts[i:])
+			router.hosts[i] = h
 		} else {
-			// handle 405
-			if allow := router.allowed(req.Path(), method, path); len(allow) > 0 {
-				res.Header("Allow", allow)
-				router.callHandler(req, res, router.middlewares, router.methodNotAllowed).End()
-				return
-			}
+			router.hosts = append(router.hosts, h)
 		}
+	}
+	return &route{
+		router: router,
+		host:   h,
+	}
+}
 
-		router.callHandler(req, res, router.middlewares, router.notFound).End()
+func (router *router) findHost(hostname []byte, values *tokensDescriptor) *host {
+	for _, h := range router.hosts {
+		if h.Matches(hostname, values) {
+			return h
+		}
 	}
+	return nil
 }
 
-func (router *router) callHandler(req Request, res Response, middlewares []Middleware, handler Handler) Result {
-	if len(middlewares) > 0 {
-		middlewareIdx := 0
-		var next Handler
-		next = func(req2 Request, res2 Response) Result {
-			middlewareIdx++
-			if middlewareIdx < len(middlewares) {
-				return middlewares[middlewareIdx](req2, res2, next)
+// URL builds the path of the route named `name`. `params` are key value pairs
+// used to fill its wildcards, eg. `URL("todos.show", "id", 42)`.
+func (router *router) URL(name string, params ...interface{}) (string, error) {
+	e, ok := router.names[name]
+	if !ok {
+		for _, m := range router.mounts {
+			url, err := m.router.URL(name, params...)
+			if err == nil {
+				return m.url(url), nil
 			}
-			return handler(req2, res2)
+			if !errors.Is(err, ErrRouteNotFound) {
+				return "", err
+			}
+		}
+		return "", errors.Wrap(ErrRouteNotFound, errors.Message(fmt.Sprintf("route '%s' not found", name)))
+	}
+	return e.url(params...)
+}
+
+// Walk calls `fn` for each registered route, in the order they were
+// registered, followed by the routes of the mounted routers. It stops at the
+// first error returned by `fn`.
+func (router *router) Walk(fn func(route RouteInfo) error) error {
+	for _, e := range router.endpoints {
+		if err := fn(e.info()); err != nil {
+			return err
 		}
-		return middlewares[0](req, res, next)
 	}
-	return handler(req, res)
+	for _, m := range router.mounts {
+		err := m.router.Walk(func(route RouteInfo) error {
+			route.Path = m.url(route.Path)
+			return fn(route)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Routes returns all registered routes, in the order they were registered,
+// followed by the routes of the mounted routers.
+func (router *router) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(router.endpoints))
+	router.Walk(func(route RouteInfo) error {
+		routes = append(routes, route)
+		return nil
+	})
+	return routes
+}
+
+// setDefaultValidator sets the validator of `r` when it does not have one.
+func setDefaultValidator(r Router, validator Validator) {
+	if r, ok := r.(*router); ok && r.validator == nil {
+		r.validator = validator
+	}
+}
+
+// Use adds middlewares to all the routes of the router, including the ones
+// already registered. It must not be called once the router is serving
+// requests, since the handlers are rebuilt without synchronization.
+func (router *router) Use(middlewares ...Middleware) {
+	router.route.Use(middlewares...)
+	if router.built {
+		router.build()
+	}
 }
 
 var (
@@ -193,9 +474,9 @@ var (
 	optionsSlashServerWide = []byte("/*")
 )
 
-func (r *router) allowed(reqPath []byte, reqMethod string, path *tokensDescriptor) (allow string) {
+func (r *router) allowed(children map[string]*node, reqPath []byte, reqMethod string, path *tokensDescriptor) (allow string) {
 	if bytes.Equal(reqPath, optionsServerWide) || bytes.Equal(reqPath, optionsSlashServerWide) { // server-wide
-		for method := range r.children {
+		for method := range children {
 			if method == "OPTIONS" {
 				continue
 			}
@@ -207,20 +488,30 @@ func (r *router) allowed(reqPath []byte, reqMethod string, path *tokensDescripto
 				allow += ", " + method
 			}
 		}
+		if _, ok := children["HEAD"]; !ok && r.autoHead {
+			if _, ok := children["GET"]; ok {
+				allow += ", HEAD"
+			}
+		}
 	} else { // specific path
-		for method := range r.children {
+		for method := range children {
 			// Skip the requested method - we already tried this one
 			if method == reqMethod || method == "OPTIONS" {
 				continue
 			}
 
-			if found, _ := r.findHandler(r.children[method], path, nil); found {
+			if found, _ := r.findHandler(children[method], path, nil); found {
 				// add request method to list of allowed methods
 				if len(allow) == 0 {
 					allow = method
 				} else {
 					allow += ", " + method
 				}
+
+				// HEAD is served by GET if not registered for the path
+				if method == "GET" && r.autoHead && reqMethod != "HEAD" && r.match(children, nil, "HEAD", nil, path, nil) == nil {
+					allow += ", HEAD"
+				}
 			}
 		}
 	}
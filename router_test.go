@@ -75,6 +75,14 @@ var _ = g.Describe("Router", func() {
 			Expect(tokens.m[3]).To(Equal([]byte("parts")))
 		})
 
+		g.It("should split the path ending with a single char token", func() {
+			path := []byte("/a/b/c/d")
+			tokens := createPathDescriptor()
+			split(path, tokens)
+			Expect(tokens.n).To(Equal(4))
+			Expect(tokens.m).To(Equal([][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}))
+		})
+
 		g.It("should split an empty path", func() {
 			path := []byte("/")
 			tokens := createPathDescriptor()
@@ -175,6 +183,46 @@ var _ = g.Describe("Router", func() {
 			Expect(router.children["POST"].wildcard).To(BeNil())
 		})
 
+		g.It("should parse a custom method", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Handle("PROPFIND", "/route", emptyHandler)
+
+			Expect(router.children).To(HaveKey("PROPFIND"))
+			Expect(router.children["PROPFIND"].children).To(HaveKey("route"))
+			Expect(router.children["PROPFIND"].children["route"].handler).NotTo(BeNil())
+		})
+
+		g.It("should panic due to an empty method", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			Expect(func() {
+				router.Handle("", "/route", emptyHandler)
+			}).To(Panic())
+		})
+
+		g.It("should parse any method", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Any("/route", emptyHandler)
+
+			Expect(router.children).To(HaveLen(len(anyMethods)))
+			for _, method := range anyMethods {
+				Expect(router.children).To(HaveKey(method))
+				Expect(router.children[method].children["route"].handler).NotTo(BeNil())
+			}
+		})
+
+		g.It("should parse multiple methods", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Match([]string{"GET", "REPORT"}, "/route", emptyHandler).Name("route")
+
+			Expect(router.children).To(HaveLen(2))
+			Expect(router.children["GET"].children["route"].handler).NotTo(BeNil())
+			Expect(router.children["REPORT"].children["route"].handler).NotTo(BeNil())
+			Expect(router.Routes()).To(Equal([]RouteInfo{
+				{Method: "GET", Path: "/route", Name: "route"},
+				{Method: "REPORT", Path: "/route", Name: "route"},
+			}))
+		})
+
 		g.It("should parse a complete static route", func() {
 			router := NewRouter(emptyRouterConfig).(*router)
 			router.Get("/this/should/be/static", emptyHandler)
@@ -422,6 +470,99 @@ var _ = g.Describe("Router", func() {
 			}).To(Panic())
 		})
 
+		g.It("should parse a route ending with a catch all", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Get("/static/*filepath", emptyHandler)
+
+			Expect(router.children["GET"].children).To(HaveKey("static"))
+			Expect(router.children["GET"].children["static"].wildcard).To(BeNil())
+			Expect(router.children["GET"].children["static"].catchAll).NotTo(BeNil())
+			Expect(router.children["GET"].children["static"].catchAll.handler).NotTo(BeNil())
+			Expect(router.children["GET"].children["static"].catchAll.names).To(Equal([]string{"filepath"}))
+		})
+
+		g.It("should parse a route with wildcards and a catch all", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Get("/:account/files/*filepath/", emptyHandler)
+
+			Expect(router.children["GET"].wildcard).NotTo(BeNil())
+			Expect(router.children["GET"].wildcard.children).To(HaveKey("files"))
+			Expect(router.children["GET"].wildcard.children["files"].catchAll).NotTo(BeNil())
+			Expect(router.children["GET"].wildcard.children["files"].catchAll.names).To(Equal([]string{"account", "filepath"}))
+		})
+
+		g.It("should panic due to a catch all not being the last token", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+
+			Expect(func() {
+				router.Get("/static/*filepath/detail", emptyHandler)
+			}).To(Panic())
+		})
+
+		g.It("should panic due to conflicting catch all routes", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Get("/static/*filepath", emptyHandler)
+			Expect(func() {
+				router.Get("/static/*path", emptyHandler)
+			}).To(Panic())
+		})
+
+		g.It("should parse constrained wildcards", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Get("/users/:id<int>", emptyHandler)
+			router.Get("/users/:name<[a-z0-9-]+>/posts", emptyHandler)
+			router.Get("/users/:id<int>/posts", emptyHandler)
+
+			users := router.children["GET"].children["users"]
+			Expect(users.wildcard).To(BeNil())
+			Expect(users.constrained).To(HaveLen(2))
+			Expect(users.constrained[0].constraint.expr).To(Equal("int"))
+			Expect(users.constrained[0].handler).NotTo(BeNil())
+			Expect(users.constrained[0].names).To(Equal([]string{"id"}))
+			Expect(users.constrained[0].children).To(HaveKey("posts"))
+			Expect(users.constrained[1].constraint.expr).To(Equal("[a-z0-9-]+"))
+			Expect(users.constrained[1].handler).To(BeNil())
+			Expect(users.constrained[1].children["posts"].names).To(Equal([]string{"name"}))
+		})
+
+		g.It("should panic due to invalid constraints", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+
+			Expect(func() {
+				router.Get("/users/:id<int", emptyHandler)
+			}).To(Panic())
+
+			Expect(func() {
+				router.Get("/users/:id<>", emptyHandler)
+			}).To(Panic())
+
+			Expect(func() {
+				router.Get("/users/:id<[a-z>", emptyHandler)
+			}).To(Panic())
+		})
+
+		g.It("should panic due to conflicting constrained routes", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Get("/users/:id<int>", emptyHandler)
+			Expect(func() {
+				router.Get("/users/:user<int>", emptyHandler)
+			}).To(Panic())
+		})
+
+		g.It("should set the handler of routes ending with /", func() {
+			router := NewRouter(emptyRouterConfig).(*router)
+			router.Get("/account/", emptyHandler)
+			router.Get("/account/:id/", emptyHandler)
+
+			Expect(router.children["GET"].handler).To(BeNil())
+			Expect(router.children["GET"].children["account"].handler).NotTo(BeNil())
+			Expect(router.children["GET"].children["account"].wildcard.handler).NotTo(BeNil())
+			Expect(router.children["GET"].children["account"].wildcard.names).To(Equal([]string{"id"}))
+			Expect(func() {
+				router.Get("/account", emptyHandler)
+			}).To(Panic())
+		})
+
 		g.It("should not match any ropute", func() {
 			router := NewRouter(emptyRouterConfig).(*router)
 			router.Get("/:account/detail", emptyHandler)
@@ -703,6 +844,209 @@ var _ = g.Describe("Router", func() {
 			Expect(value3).To(Equal(2))
 		})
 
+		g.It("should resolve a catch all route", func() {
+			value := 1
+			router.Get("/static/*filepath", func(req Request, res Response) Result {
+				Expect(req.Param("filepath")).To(Equal("css/app/main.css"))
+				value = 2
+				return res.End()
+			})
+
+			router.Handler()(createRequestCtxFromPath("GET", "/static/css/app/main.css"))
+
+			Expect(value).To(Equal(2))
+		})
+
+		g.It("should resolve a catch all route with a single token", func() {
+			value := 1
+			router.Get("/:account/files/*filepath", func(req Request, res Response) Result {
+				Expect(req.Param("account")).To(Equal("account1"))
+				Expect(req.Param("filepath")).To(Equal("report.pdf"))
+				value = 2
+				return res.End()
+			})
+
+			router.Handler()(createRequestCtxFromPath("GET", "/account1/files/report.pdf"))
+
+			Expect(value).To(Equal(2))
+		})
+
+		g.It("should prefer static and wildcard routes over the catch all", func() {
+			calls := make([]string, 0)
+			router.Get("/static/*filepath", func(req Request, res Response) Result {
+				calls = append(calls, "catchall:"+req.Param("filepath"))
+				return res.End()
+			})
+			router.Get("/static/index", func(req Request, res Response) Result {
+				calls = append(calls, "static")
+				return res.End()
+			})
+
+			router.Handler()(createRequestCtxFromPath("GET", "/static/index"))
+			router.Handler()(createRequestCtxFromPath("GET", "/static/index.html"))
+
+			Expect(calls).To(Equal([]string{"static", "catchall:index.html"}))
+		})
+
+		g.It("should call the not found callback when the catch all has nothing to capture", func() {
+			value := 1
+			router := NewRouter(RouterConfig{NotFound: func(req Request, res Response) Result {
+				value = 2
+				return res.End()
+			}})
+			router.Get("/static/*filepath", func(req Request, res Response) Result {
+				g.Fail("should not be called")
+				return res.End()
+			})
+
+			router.Handler()(createRequestCtxFromPath("GET", "/static/"))
+
+			Expect(value).To(Equal(2))
+		})
+
+		g.Describe("Priority", func() {
+			var calls []string
+
+			record := func(name string, params ...string) Handler {
+				return func(req Request, res Response) Result {
+					call := name
+					for _, p := range params {
+						call += ":" + req.Param(p)
+					}
+					calls = append(calls, call)
+					return res.End()
+				}
+			}
+
+			g.BeforeEach(func() {
+				calls = make([]string, 0)
+			})
+
+			g.It("should backtrack to the wildcard when the static branch dead-ends", func() {
+				router.Get("/users/me/settings", record("settings"))
+				router.Get("/users/:id/posts", record("posts", "id"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/users/me/settings"))
+				router.Handler()(createRequestCtxFromPath("GET", "/users/me/posts"))
+				router.Handler()(createRequestCtxFromPath("GET", "/users/123/posts"))
+
+				Expect(calls).To(Equal([]string{"settings", "posts:me", "posts:123"}))
+			})
+
+			g.It("should backtrack to the wildcard when the static node has no handler", func() {
+				router.Get("/users/me/settings", record("settings"))
+				router.Get("/users/:id", record("show", "id"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/users/me"))
+
+				Expect(calls).To(Equal([]string{"show:me"}))
+			})
+
+			g.It("should prefer static over wildcard over catch all", func() {
+				router.Get("/files/*filepath", record("catchall", "filepath"))
+				router.Get("/files/:name", record("wildcard", "name"))
+				router.Get("/files/index", record("static"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/files/index"))
+				router.Handler()(createRequestCtxFromPath("GET", "/files/readme"))
+				router.Handler()(createRequestCtxFromPath("GET", "/files/docs/readme"))
+
+				Expect(calls).To(Equal([]string{"static", "wildcard:readme", "catchall:docs/readme"}))
+			})
+
+			g.It("should discard values captured by a failed wildcard branch", func() {
+				router.Get("/:account/:project/settings", record("settings", "account", "project"))
+				router.Get("/:account/*filepath", record("catchall", "account", "filepath"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/account1/project1/settings"))
+				router.Handler()(createRequestCtxFromPath("GET", "/account1/project1/history"))
+
+				Expect(calls).To(Equal([]string{"settings:account1:project1", "catchall:account1:project1/history"}))
+			})
+
+			g.It("should backtrack through multiple levels", func() {
+				router.Get("/a/b/c/d", record("static"))
+				router.Get("/a/:x/c/e", record("wildcard1", "x"))
+				router.Get("/:y/b/c/f", record("wildcard2", "y"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/a/b/c/d"))
+				router.Handler()(createRequestCtxFromPath("GET", "/a/b/c/e"))
+				router.Handler()(createRequestCtxFromPath("GET", "/a/b/c/f"))
+
+				Expect(calls).To(Equal([]string{"static", "wildcard1:b", "wildcard2:a"}))
+			})
+
+			g.It("should match constrained wildcards before the wildcard", func() {
+				router.Get("/users/:id<int>", record("int", "id"))
+				router.Get("/users/:uuid<uuid>", record("uuid", "uuid"))
+				router.Get("/users/:name<[a-z-]+>", record("regex", "name"))
+				router.Get("/users/:any", record("wildcard", "any"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/users/42"))
+				router.Handler()(createRequestCtxFromPath("GET", "/users/6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+				router.Handler()(createRequestCtxFromPath("GET", "/users/snake-eyes"))
+				router.Handler()(createRequestCtxFromPath("GET", "/users/Snake_Eyes"))
+
+				Expect(calls).To(Equal([]string{
+					"int:42",
+					"uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+					"regex:snake-eyes",
+					"wildcard:Snake_Eyes",
+				}))
+			})
+
+			g.It("should call the not found callback when the constraint does not match", func() {
+				router := NewRouter(RouterConfig{NotFound: record("notfound")})
+				router.Get("/users/:id<int>", record("int", "id"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/users/42"))
+				router.Handler()(createRequestCtxFromPath("GET", "/users/me"))
+
+				Expect(calls).To(Equal([]string{"int:42", "notfound"}))
+			})
+
+			g.It("should consider constraints when listing allowed methods", func() {
+				router := DefaultRouter()
+				router.Get("/users/:id<int>", record("show", "id"))
+				router.Put("/users/:name<alpha>", record("update", "name"))
+
+				ctx := createRequestCtxFromPath("POST", "/users/42")
+				router.Handler()(ctx)
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("GET", "HEAD", "OPTIONS"))
+
+				ctx = createRequestCtxFromPath("OPTIONS", "/users/me")
+				router.Handler()(ctx)
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("PUT", "OPTIONS"))
+
+				ctx = createRequestCtxFromPath("POST", "/users/me42")
+				router.Handler()(ctx)
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusNotFound))
+			})
+
+			g.It("should call the not found callback when no branch matches", func() {
+				router := NewRouter(RouterConfig{NotFound: record("notfound")})
+				router.Get("/users/me/settings", record("settings"))
+				router.Get("/users/:id/posts", record("posts", "id"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/users/me/history"))
+
+				Expect(calls).To(Equal([]string{"notfound"}))
+			})
+
+			g.It("should consider backtracking when listing allowed methods", func() {
+				router := DefaultRouter()
+				router.Get("/users/me/settings", record("settings"))
+				router.Post("/users/:id/posts", record("posts", "id"))
+
+				ctx := createRequestCtxFromPath("PUT", "/users/me/posts")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("POST", "OPTIONS"))
+			})
+		})
+
 		g.It("should call the not found callback for the index route", func() {
 			value1 := 1
 
@@ -819,7 +1163,7 @@ var _ = g.Describe("Router", func() {
 			ctx := createRequestCtxFromPath("POST", "/value1/transactions")
 			router.Handler()(ctx)
 			methods := string(ctx.Response.Header.Peek("Allow"))
-			Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "OPTIONS"))
+			Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "HEAD", "OPTIONS"))
 			Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
 		})
 
@@ -847,11 +1191,224 @@ var _ = g.Describe("Router", func() {
 			ctx.Request.Header.Set("Accept", "application/json, text/html, text/plain")
 			router.Handler()(ctx)
 			methods := string(ctx.Response.Header.Peek("Allow"))
-			Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "OPTIONS"))
+			Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "HEAD", "OPTIONS"))
 			Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
 			Expect(string(ctx.Response.Header.Peek("Content-Type"))).To(Equal("application/json; charset=utf-8"))
 		})
 
+		g.Describe("Host", func() {
+			var calls []string
+
+			record := func(name string, params ...string) Handler {
+				return func(req Request, res Response) Result {
+					call := name
+					for _, p := range params {
+						call += ":" + req.Param(p)
+					}
+					calls = append(calls, call)
+					return res.End()
+				}
+			}
+
+			createRequestCtx := func(method, host, path string) *fasthttp.RequestCtx {
+				ctx := createRequestCtxFromPath(method, path)
+				ctx.Request.URI().SetHost(host)
+				return ctx
+			}
+
+			g.BeforeEach(func() {
+				calls = make([]string, 0)
+				router = NewRouter(RouterConfig{NotFound: record("notfound")})
+			})
+
+			g.It("should dispatch by host before matching the path", func() {
+				router.Get("/todos", record("default"))
+				router.Host("api.example.com").Get("/todos", record("api"))
+				router.Host("admin.example.com").Prefix("/todos").Get("/:id", record("admin", "id"))
+
+				router.Handler()(createRequestCtx("GET", "api.example.com", "/todos"))
+				router.Handler()(createRequestCtx("GET", "admin.example.com:8080", "/todos/1"))
+				router.Handler()(createRequestCtx("GET", "www.example.com", "/todos"))
+
+				Expect(calls).To(Equal([]string{"api", "admin:1", "default"}))
+			})
+
+			g.It("should expose host params", func() {
+				router.Host(":tenant.example.com").Get("/todos/:id", record("todos", "tenant", "id"))
+
+				router.Handler()(createRequestCtx("GET", "acme.example.com", "/todos/1"))
+
+				Expect(calls).To(Equal([]string{"todos:acme:1"}))
+			})
+
+			g.It("should prefer hosts without wildcards", func() {
+				router.Host(":tenant.example.com").Get("/", record("tenant", "tenant"))
+				router.Host("admin.example.com").Get("/", record("admin"))
+
+				router.Handler()(createRequestCtx("GET", "admin.example.com", "/"))
+				router.Handler()(createRequestCtx("GET", "acme.example.com", "/"))
+
+				Expect(calls).To(Equal([]string{"admin", "tenant:acme"}))
+			})
+
+			g.It("should reuse the routes of an already registered host", func() {
+				router.Host("api.example.com").Get("/todos", record("index"))
+				router.Host("API.example.com").Post("/todos", record("create"))
+
+				router.Handler()(createRequestCtx("GET", "api.example.com", "/todos"))
+				router.Handler()(createRequestCtx("POST", "api.example.com", "/todos"))
+
+				Expect(calls).To(Equal([]string{"index", "create"}))
+			})
+
+			g.It("should not fall back to the default routes when the host matches", func() {
+				router.Get("/health", record("health"))
+				router.Host("api.example.com").Get("/todos", record("api"))
+
+				router.Handler()(createRequestCtx("GET", "api.example.com", "/health"))
+
+				Expect(calls).To(Equal([]string{"notfound"}))
+			})
+
+			g.It("should list the allowed methods of the host routes", func() {
+				router.Get("/todos", emptyHandler)
+				router.Host("api.example.com").Post("/todos", emptyHandler)
+
+				ctx := createRequestCtx("OPTIONS", "api.example.com", "/todos")
+				router.Handler()(ctx)
+
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("POST", "OPTIONS"))
+			})
+		})
+
+		g.Describe("Custom methods", func() {
+			g.It("should resolve a custom method", func() {
+				value := 1
+				router.Handle("PURGE", "/cache/:key", func(req Request, res Response) Result {
+					Expect(req.Param("key")).To(Equal("todos"))
+					value = 2
+					return res.End()
+				})
+
+				router.Handler()(createRequestCtxFromPath("PURGE", "/cache/todos"))
+
+				Expect(value).To(Equal(2))
+			})
+
+			g.It("should resolve any method", func() {
+				methods := make([]string, 0)
+				router.Any("/proxy", func(req Request, res Response) Result {
+					methods = append(methods, string(req.Method()))
+					return res.End()
+				})
+
+				for _, method := range []string{"GET", "POST", "DELETE", "OPTIONS"} {
+					router.Handler()(createRequestCtxFromPath(method, "/proxy"))
+				}
+
+				Expect(methods).To(Equal([]string{"GET", "POST", "DELETE", "OPTIONS"}))
+			})
+
+			g.It("should resolve only the matched methods", func() {
+				router := DefaultRouter()
+				methods := make([]string, 0)
+				router.Match([]string{"PROPFIND", "REPORT"}, "/dav", func(req Request, res Response) Result {
+					methods = append(methods, string(req.Method()))
+					return res.End()
+				})
+
+				router.Handler()(createRequestCtxFromPath("REPORT", "/dav"))
+				ctx := createRequestCtxFromPath("GET", "/dav")
+				router.Handler()(ctx)
+
+				Expect(methods).To(Equal([]string{"REPORT"}))
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("PROPFIND", "REPORT", "OPTIONS"))
+			})
+
+			g.It("should list custom methods on OPTIONS requests", func() {
+				router.Get("/dav", emptyHandler)
+				router.Handle("PROPFIND", "/dav", emptyHandler)
+
+				ctx := createRequestCtxFromPath("OPTIONS", "/dav")
+				router.Handler()(ctx)
+
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("GET", "HEAD", "PROPFIND", "OPTIONS"))
+			})
+		})
+
+		g.Describe("HEAD requests", func() {
+			g.It("should serve HEAD through the GET handler without the body", func() {
+				router.Get("/todos", func(req Request, res Response) Result {
+					return res.Data("hello")
+				})
+
+				ctx := createRequestCtxFromPath("HEAD", "/todos")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusOK))
+				Expect(ctx.Response.SkipBody).To(BeTrue())
+				raw := ctx.Response.String()
+				Expect(raw).To(ContainSubstring("Content-Length: 5"))
+				Expect(raw).NotTo(ContainSubstring("hello"))
+			})
+
+			g.It("should prefer the HEAD handler when registered", func() {
+				calls := make([]string, 0)
+				router.Get("/todos", func(req Request, res Response) Result {
+					calls = append(calls, "get")
+					return res.End()
+				})
+				router.Head("/todos", func(req Request, res Response) Result {
+					calls = append(calls, "head")
+					return res.End()
+				})
+
+				router.Handler()(createRequestCtxFromPath("HEAD", "/todos"))
+
+				Expect(calls).To(Equal([]string{"head"}))
+			})
+
+			g.It("should expose the params of the GET route", func() {
+				value := ""
+				router.Get("/todos/:id", func(req Request, res Response) Result {
+					value = req.Param("id")
+					return res.End()
+				})
+
+				router.Handler()(createRequestCtxFromPath("HEAD", "/todos/1"))
+
+				Expect(value).To(Equal("1"))
+			})
+
+			g.It("should not serve HEAD through the GET handler when disabled", func() {
+				router := NewRouter(RouterConfig{DisableAutoHead: true})
+				router.Get("/todos", func(req Request, res Response) Result {
+					g.Fail("should not be called")
+					return res.End()
+				})
+
+				ctx := createRequestCtxFromPath("HEAD", "/todos")
+				router.Handler()(ctx)
+
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusMethodNotAllowed))
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("GET", "OPTIONS"))
+			})
+
+			g.It("should not list HEAD twice when registered", func() {
+				router.Get("/todos", emptyHandler)
+				router.Head("/todos", emptyHandler)
+
+				ctx := createRequestCtxFromPath("OPTIONS", "/todos")
+				router.Handler()(ctx)
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("GET", "HEAD", "OPTIONS"))
+
+				ctx = createRequestCtxFromPath("OPTIONS", "*")
+				router.Handler()(ctx)
+				Expect(strings.Split(string(ctx.Response.Header.Peek("Allow")), ", ")).To(ConsistOf("GET", "HEAD", "OPTIONS"))
+			})
+		})
+
 		g.Describe("Options request", func() {
 			g.It("should resolve server-wide", func() {
 				router.Get("/todos", emptyHandler)
@@ -859,7 +1416,7 @@ var _ = g.Describe("Router", func() {
 				ctx := createRequestCtxFromPath("OPTIONS", "*")
 				router.Handler()(ctx)
 				methods := string(ctx.Response.Header.Peek("Allow"))
-				Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "POST", "OPTIONS"))
+				Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "HEAD", "POST", "OPTIONS"))
 			})
 
 			g.It("should resolve server-wide²", func() {
@@ -868,7 +1425,7 @@ var _ = g.Describe("Router", func() {
 				ctx := createRequestCtxFromPath("OPTIONS", "/*")
 				router.Handler()(ctx)
 				methods := string(ctx.Response.Header.Peek("Allow"))
-				Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "POST", "OPTIONS"))
+				Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "HEAD", "POST", "OPTIONS"))
 			})
 
 			g.It("should resolve specific path", func() {
@@ -877,7 +1434,7 @@ var _ = g.Describe("Router", func() {
 				ctx := createRequestCtxFromPath("OPTIONS", "/todos")
 				router.Handler()(ctx)
 				methods := string(ctx.Response.Header.Peek("Allow"))
-				Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "POST", "OPTIONS"))
+				Expect(strings.Split(methods, ", ")).To(ConsistOf("GET", "HEAD", "POST", "OPTIONS"))
 			})
 		})
 
@@ -925,7 +1482,7 @@ var _ = g.Describe("Router", func() {
 				Expect(calls[2]).To(Equal("endpoint"))
 			})
 
-			g.It("should not call middlewares added after prefix/group", func() {
+			g.It("should call router middlewares added after the routes", func() {
 				calls := make([]string, 0)
 				router.Use(func(req Request, res Response, next Handler) Result {
 					calls = append(calls, "middleware1")
@@ -945,10 +1502,169 @@ var _ = g.Describe("Router", func() {
 					return next(req, res)
 				})
 				router.Handler()(createRequestCtxFromPath("GET", "/api/account/transactions"))
-				Expect(calls).To(HaveLen(3))
-				Expect(calls[0]).To(Equal("middleware1"))
-				Expect(calls[1]).To(Equal("middleware2"))
-				Expect(calls[2]).To(Equal("endpoint"))
+				Expect(calls).To(Equal([]string{"middleware1", "middleware2", "middleware3", "endpoint"}))
+			})
+
+			g.It("should call router middlewares added after the handler is built", func() {
+				calls := make([]string, 0)
+				router.Get("/todos", func(req Request, res Response) Result {
+					calls = append(calls, "endpoint")
+					return res.End()
+				})
+				handler := router.Handler()
+
+				router.Use(func(req Request, res Response, next Handler) Result {
+					calls = append(calls, "middleware")
+					return next(req, res)
+				})
+				router.Get("/users", func(req Request, res Response) Result {
+					calls = append(calls, "users")
+					return res.End()
+				})
+				handler(createRequestCtxFromPath("GET", "/todos"))
+				handler(createRequestCtxFromPath("GET", "/users"))
+				handler(createRequestCtxFromPath("GET", "/projects"))
+
+				Expect(calls).To(Equal([]string{"middleware", "endpoint", "middleware", "users", "middleware"}))
+			})
+
+			g.It("should call router middlewares before the group ones regardless of the order they were added", func() {
+				calls := make([]string, 0)
+				api := router.Prefix("/api").With(func(req Request, res Response, next Handler) Result {
+					calls = append(calls, "group")
+					return next(req, res)
+				})
+				api.Get("/todos", func(req Request, res Response) Result {
+					calls = append(calls, "endpoint")
+					return res.End()
+				})
+				router.Use(func(req Request, res Response, next Handler) Result {
+					calls = append(calls, "router")
+					return next(req, res)
+				})
+
+				router.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+				Expect(calls).To(Equal([]string{"router", "group", "endpoint"}))
+			})
+
+			g.It("should call router middlewares added after the routes for method not allowed and options", func() {
+				calls := make([]string, 0)
+				router.Get("/todos", emptyHandler)
+				router.Use(func(req Request, res Response, next Handler) Result {
+					calls = append(calls, string(req.Method()))
+					return next(req, res)
+				})
+
+				router.Handler()(createRequestCtxFromPath("POST", "/todos"))
+				router.Handler()(createRequestCtxFromPath("OPTIONS", "/todos"))
+				Expect(calls).To(Equal([]string{"POST", "OPTIONS"}))
+			})
+
+			g.It("should call the route middlewares after the group ones", func() {
+				calls := make([]string, 0)
+				middleware := func(name string) Middleware {
+					return func(req Request, res Response, next Handler) Result {
+						calls = append(calls, name)
+						return next(req, res)
+					}
+				}
+				router.Use(middleware("router"))
+				api := router.Prefix("/api").With(middleware("group"))
+				api.Get("/todos", func(req Request, res Response) Result {
+					calls = append(calls, "endpoint")
+					return res.End()
+				}, middleware("route1"), middleware("route2"))
+				api.Get("/users", func(req Request, res Response) Result {
+					calls = append(calls, "users")
+					return res.End()
+				})
+
+				router.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+				router.Handler()(createRequestCtxFromPath("GET", "/api/users"))
+				Expect(calls).To(Equal([]string{"router", "group", "route1", "route2", "endpoint", "router", "group", "users"}))
+			})
+
+			g.It("should the route middleware prevent the handler from being called", func() {
+				router.Post("/todos", func(req Request, res Response) Result {
+					g.Fail("this endpoint should not be called")
+					return res.End()
+				}, func(req Request, res Response, next Handler) Result {
+					return res.Status(StatusUnauthorized).Data("unauthorized")
+				})
+
+				ctx := createRequestCtxFromPath("POST", "/todos")
+				router.Handler()(ctx)
+				Expect(ctx.Response.StatusCode()).To(Equal(StatusUnauthorized))
+			})
+
+			g.It("should call the route middlewares for all the methods of Match", func() {
+				calls := make([]string, 0)
+				router.Match([]string{"PUT", "PATCH"}, "/todos/:id", emptyHandler, func(req Request, res Response, next Handler) Result {
+					calls = append(calls, string(req.Method()))
+					return next(req, res)
+				})
+
+				router.Handler()(createRequestCtxFromPath("PUT", "/todos/1"))
+				router.Handler()(createRequestCtxFromPath("PATCH", "/todos/1"))
+				Expect(calls).To(Equal([]string{"PUT", "PATCH"}))
+			})
+
+			g.It("should not share middlewares between sibling groups", func() {
+				calls := make([]string, 0)
+				middleware := func(name string) Middleware {
+					return func(req Request, res Response, next Handler) Result {
+						calls = append(calls, name)
+						return next(req, res)
+					}
+				}
+				api := router.Prefix("/api").With(middleware("api1")).With(middleware("api2")).With(middleware("api3"))
+				api.With(middleware("todos")).Get("/todos", emptyHandler)
+				api.With(middleware("users")).Get("/users", emptyHandler)
+
+				router.Handler()(createRequestCtxFromPath("GET", "/api/todos"))
+				Expect(calls).To(Equal([]string{"api1", "api2", "api3", "todos"}))
+			})
+
+			g.It("should not share middlewares between sibling prefixes", func() {
+				calls := make([]string, 0)
+				middleware := func(name string) Middleware {
+					return func(req Request, res Response, next Handler) Result {
+						calls = append(calls, name)
+						return next(req, res)
+					}
+				}
+				api := router.Prefix("/api").With(middleware("api1")).With(middleware("api2")).With(middleware("api3"))
+				a := api.Prefix("/a")
+				a.Use(middleware("a"))
+				a.Get("/x", emptyHandler)
+				b := api.Prefix("/b")
+				b.Use(middleware("b"))
+				b.Get("/y", emptyHandler)
+
+				router.Handler()(createRequestCtxFromPath("GET", "/api/a/x"))
+				Expect(calls).To(Equal([]string{"api1", "api2", "api3", "a"}))
+
+				calls = calls[:0]
+				router.Handler()(createRequestCtxFromPath("GET", "/api/b/y"))
+				Expect(calls).To(Equal([]string{"api1", "api2", "api3", "b"}))
+			})
+
+			g.It("should not change the middlewares of registered routes using the group afterwards", func() {
+				calls := make([]string, 0)
+				middleware := func(name string) Middleware {
+					return func(req Request, res Response, next Handler) Result {
+						calls = append(calls, name)
+						return next(req, res)
+					}
+				}
+				api := router.Prefix("/api").With(middleware("api1"), middleware("api2"), middleware("api3"))
+				group := api.Prefix("/a")
+				group.Get("/x", emptyHandler)
+				sibling := api.Prefix("/b")
+				sibling.Use(middleware("b"))
+
+				router.Handler()(createRequestCtxFromPath("GET", "/api/a/x"))
+				Expect(calls).To(Equal([]string{"api1", "api2", "api3"}))
 			})
 
 			g.It("should the middleware prevent a handler and  for being called", func() {
@@ -1143,3 +1859,60 @@ func BenchmarkRouter_HandlerWithMiddleware5(b *testing.B) {
 func BenchmarkRouter_HandlerWithMiddleware10(b *testing.B) {
 	benchmarkHandlerWithMiddlewares(10, b)
 }
+
+func benchmarkHandlerManyRoutes(config RouterConfig, b *testing.B) {
+	router := NewRouter(config)
+	for _, resource := range []string{"users", "todos", "projects", "teams", "comments"} {
+		router.Prefix("/api/v1/" + resource).Group(func(r Routable) {
+			r.Get("/", emptyHandler)
+			r.Post("/", emptyHandler)
+			r.Get("/:id<int>", emptyHandler)
+			r.Put("/:id<int>", emptyHandler)
+			r.Get("/:id/history", emptyHandler)
+			r.Get("/:id/attachments/*filepath", emptyHandler)
+		})
+	}
+	ctx := fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/v1/comments/42/history")
+	h := router.Handler()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h(&ctx)
+	}
+}
+
+func BenchmarkRouter_HandlerManyRoutes(b *testing.B) {
+	benchmarkHandlerManyRoutes(RouterConfig{}, b)
+}
+
+func BenchmarkRadixRouter_HandlerManyRoutes(b *testing.B) {
+	benchmarkHandlerManyRoutes(RouterConfig{RadixTree: true}, b)
+}
+
+func BenchmarkRadixRouter_Handler2LevelsWithParams(b *testing.B) {
+	router := NewRouter(RouterConfig{RadixTree: true})
+	router.Get("/:id/:name", emptyHandler)
+	ctx := fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/id/meu-nome")
+
+	h := router.Handler()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h(&ctx)
+	}
+}
+
+func BenchmarkRouter_NotFoundWithMiddleware(b *testing.B) {
+	router := DefaultRouter()
+	router.Use(func(req Request, res Response, next Handler) Result {
+		return next(req, res)
+	})
+	router.Get("/", emptyHandler)
+	ctx := fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/not-found")
+	h := router.Handler()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h(&ctx)
+	}
+}
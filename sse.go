@@ -0,0 +1,191 @@
+package hermes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errEventStreamClosed = errors.New("event stream closed")
+	errEventLineBreak    = errors.New("the event and the id cannot have line breaks")
+
+	eventStreamContentType = []byte("text/event-stream")
+)
+
+// EventStream sends Server-Sent Events to the client.
+type EventStream interface {
+	// Send sends an event with the data, which is sent as is when it is a
+	// string or a `[]byte`, and as JSON otherwise. The event and the id are
+	// omitted when empty.
+	Send(event, id string, data interface{}) error
+
+	// Retry tells the client how long to wait before reconnecting.
+	Retry(d time.Duration) error
+
+	// Comment sends a comment, which is ignored by the client.
+	Comment(text string) error
+
+	// Heartbeat sends a comment at each interval, until the stream ends,
+	// keeping the connection alive and detecting when the client is gone.
+	// A non positive interval stops it.
+	Heartbeat(interval time.Duration)
+
+	// LastEventID returns the id of the last event received by the client,
+	// informed when it reconnects.
+	LastEventID() string
+
+	// Context returns the context of the request, which is canceled when
+	// the client disconnects, as soon as writing to it fails.
+	Context() context.Context
+}
+
+type eventStream struct {
+	mutex       sync.Mutex
+	w           *bufio.Writer
+	ctx         context.Context
+	cancel      context.CancelFunc
+	lastEventID string
+	closed      bool
+	heartbeat   chan struct{}
+}
+
+// write writes to the client, flushing it. The context is canceled when it
+// fails.
+func (stream *eventStream) write(fn func(w *bufio.Writer)) error {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	if stream.closed {
+		return errEventStreamClosed
+	}
+	if err := stream.ctx.Err(); err != nil {
+		return err
+	}
+	fn(stream.w)
+	if err := stream.w.Flush(); err != nil {
+		stream.cancel()
+		return err
+	}
+	return nil
+}
+
+func (stream *eventStream) Send(event, id string, data interface{}) error {
+	if strings.ContainsAny(event, "\r\n") || strings.ContainsAny(id, "\r\n") {
+		return errEventLineBreak
+	}
+
+	var payload []byte
+	switch v := data.(type) {
+	case string:
+		payload = []byte(v)
+	case []byte:
+		payload = v
+	default:
+		var err error
+		if payload, err = currentJSONCodec().Marshal(v); err != nil {
+			return err
+		}
+	}
+	payload = bytes.Replace(payload, []byte("\r\n"), []byte("\n"), -1)
+	payload = bytes.Replace(payload, []byte("\r"), []byte("\n"), -1)
+
+	return stream.write(func(w *bufio.Writer) {
+		if event != "" {
+			w.WriteString("event: ")
+			w.WriteString(event)
+			w.WriteByte('\n')
+		}
+		if id != "" {
+			w.WriteString("id: ")
+			w.WriteString(id)
+			w.WriteByte('\n')
+		}
+		for _, line := range bytes.Split(payload, []byte{'\n'}) {
+			w.WriteString("data: ")
+			w.Write(line)
+			w.WriteByte('\n')
+		}
+		w.WriteByte('\n')
+	})
+}
+
+func (stream *eventStream) Retry(d time.Duration) error {
+	return stream.write(func(w *bufio.Writer) {
+		w.WriteString("retry: ")
+		w.WriteString(strconv.FormatInt(int64(d/time.Millisecond), 10))
+		w.WriteString("\n\n")
+	})
+}
+
+func (stream *eventStream) Comment(text string) error {
+	return stream.write(func(w *bufio.Writer) {
+		for _, line := range strings.Split(strings.Replace(text, "\r", "", -1), "\n") {
+			w.WriteString(": ")
+			w.WriteString(line)
+			w.WriteByte('\n')
+		}
+		w.WriteByte('\n')
+	})
+}
+
+func (stream *eventStream) Heartbeat(interval time.Duration) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	if stream.closed {
+		return
+	}
+	if stream.heartbeat != nil {
+		close(stream.heartbeat)
+		stream.heartbeat = nil
+	}
+	if interval <= 0 {
+		return
+	}
+	done := make(chan struct{})
+	stream.heartbeat = done
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if stream.Comment("heartbeat") != nil {
+					return
+				}
+			case <-done:
+				return
+			case <-stream.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (stream *eventStream) LastEventID() string {
+	return stream.lastEventID
+}
+
+func (stream *eventStream) Context() context.Context {
+	return stream.ctx
+}
+
+// close stops the heartbeat, preventing further writes, since the writer is
+// no longer valid.
+func (stream *eventStream) close() {
+	stream.mutex.Lock()
+	stream.closed = true
+	if stream.heartbeat != nil {
+		close(stream.heartbeat)
+		stream.heartbeat = nil
+	}
+	stream.mutex.Unlock()
+	stream.cancel()
+}
@@ -0,0 +1,218 @@
+package hermes
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+var _ = Describe("Hermes", func() {
+	Describe("Server-Sent Events", func() {
+		var (
+			ln     *fasthttputil.InmemoryListener
+			router Router
+		)
+
+		BeforeEach(func() {
+			router = DefaultRouter()
+			ln = fasthttputil.NewInmemoryListener()
+			go (&fasthttp.Server{Handler: func(ctx *fasthttp.RequestCtx) {
+				router.Handler()(ctx)
+			}}).Serve(ln)
+		})
+
+		AfterEach(func() {
+			ln.Close()
+		})
+
+		// open sends a request for the path, returning the connection and a
+		// reader positioned after the headers.
+		open := func(path string, headers ...string) (net.Conn, *bufio.Reader, string) {
+			conn, err := ln.Dial()
+			Expect(err).ToNot(HaveOccurred())
+			request := "GET " + path + " HTTP/1.1\r\nHost: hermes\r\n"
+			for _, header := range headers {
+				request += header + "\r\n"
+			}
+			_, err = conn.Write([]byte(request + "\r\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			br := bufio.NewReader(conn)
+			var head string
+			for {
+				line, err := br.ReadString('\n')
+				Expect(err).ToNot(HaveOccurred())
+				if line == "\r\n" {
+					return conn, br, head
+				}
+				head += line
+			}
+		}
+
+		// readUntil reads the chunked body until it contains `s`, returning
+		// the data read, without the chunk sizes.
+		readUntil := func(br *bufio.Reader, s string) string {
+			var body string
+			for !strings.Contains(body, s) {
+				size, err := br.ReadString('\n')
+				Expect(err).ToNot(HaveOccurred())
+				Expect(size).ToNot(Equal("0\r\n"))
+				chunk, err := br.ReadString('\n')
+				Expect(err).ToNot(HaveOccurred())
+				for !strings.HasSuffix(chunk, "\r\n") || len(chunk) < 2 {
+					more, err := br.ReadString('\n')
+					Expect(err).ToNot(HaveOccurred())
+					chunk += more
+				}
+				body += strings.TrimSuffix(chunk, "\r\n")
+			}
+			return body
+		}
+
+		It("should send events", func() {
+			router.Get("/todos/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					if err := stream.Retry(3 * time.Second); err != nil {
+						return err
+					}
+					if err := stream.Send("created", "1", map[string]interface{}{"title": "Buy milk"}); err != nil {
+						return err
+					}
+					if err := stream.Send("", "", "first line\nsecond line"); err != nil {
+						return err
+					}
+					return stream.Comment("bye")
+				})
+			})
+
+			conn, br, head := open("/todos/events")
+			defer conn.Close()
+
+			Expect(head).To(ContainSubstring("HTTP/1.1 200 OK"))
+			Expect(head).To(ContainSubstring("Content-Type: text/event-stream"))
+			Expect(head).To(ContainSubstring("Cache-Control: no-cache"))
+			Expect(head).To(ContainSubstring("Transfer-Encoding: chunked"))
+			Expect(readUntil(br, ": bye\n\n")).To(Equal(
+				"retry: 3000\n\n" +
+					"event: created\nid: 1\ndata: {\"title\":\"Buy milk\"}\n\n" +
+					"data: first line\ndata: second line\n\n" +
+					": bye\n\n",
+			))
+		})
+
+		It("should reject events and ids with line breaks", func() {
+			errs := make(chan error, 2)
+			router.Get("/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					errs <- stream.Send("created\n", "", "data")
+					errs <- stream.Send("", "1\r", "data")
+					return nil
+				})
+			})
+
+			conn, _, _ := open("/events")
+			defer conn.Close()
+
+			Expect(<-errs).To(MatchError(errEventLineBreak))
+			Expect(<-errs).To(MatchError(errEventLineBreak))
+		})
+
+		It("should inform the last event id", func() {
+			router.Get("/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					return stream.Send("resumed", "", stream.LastEventID())
+				})
+			})
+
+			conn, br, _ := open("/events", "Last-Event-ID: 42")
+			defer conn.Close()
+
+			Expect(readUntil(br, "\n\n")).To(Equal("event: resumed\ndata: 42\n\n"))
+		})
+
+		It("should send heartbeats", func() {
+			router.Get("/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					stream.Heartbeat(10 * time.Millisecond)
+					<-stream.Context().Done()
+					return nil
+				})
+			})
+
+			conn, br, _ := open("/events")
+			defer conn.Close()
+
+			Expect(readUntil(br, ": heartbeat\n\n: heartbeat\n\n")).To(HavePrefix(": heartbeat\n\n"))
+		})
+
+		It("should stop the heartbeats with a non positive interval", func() {
+			router.Get("/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					stream.Heartbeat(0)
+					stream.Heartbeat(-time.Second)
+					stream.Heartbeat(10 * time.Millisecond)
+					stream.Heartbeat(0)
+					time.Sleep(50 * time.Millisecond)
+					return stream.Send("", "", "done")
+				})
+			})
+
+			conn, br, _ := open("/events")
+			defer conn.Close()
+
+			Expect(readUntil(br, "\n\n")).To(Equal("data: done\n\n"))
+		})
+
+		It("should cancel the context of the request when the client disconnects", func(done Done) {
+			type contextKey string
+			disconnected := make(chan interface{})
+			router.Use(func(req Request, res Response, next Handler) Result {
+				return next(req.WithContext(context.WithValue(req.Context(), contextKey("user"), "Snake Eyes")), res)
+			})
+			router.Get("/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					stream.Heartbeat(10 * time.Millisecond)
+					if err := stream.Send("", "", "connected"); err != nil {
+						return err
+					}
+					<-stream.Context().Done()
+					disconnected <- stream.Context().Value(contextKey("user"))
+					return stream.Context().Err()
+				})
+			})
+
+			conn, br, _ := open("/events")
+			readUntil(br, "data: connected\n\n")
+			Expect(conn.Close()).To(Succeed())
+
+			Expect(<-disconnected).To(Equal("Snake Eyes"))
+			close(done)
+		}, 1)
+
+		It("should not write after the stream ends", func() {
+			streams := make(chan EventStream, 1)
+			router.Get("/events", func(req Request, res Response) Result {
+				return res.SSE(func(stream EventStream) error {
+					streams <- stream
+					return nil
+				})
+			})
+
+			conn, _, _ := open("/events")
+			defer conn.Close()
+
+			stream := <-streams
+			Eventually(func() error {
+				return stream.Send("", "", "late")
+			}).Should(MatchError(errEventStreamClosed))
+			Expect(stream.Context().Err()).To(Equal(context.Canceled))
+		})
+	})
+})
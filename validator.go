@@ -0,0 +1,117 @@
+package hermes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lab259/errors/v2"
+)
+
+var (
+	ValidationErrorCode    = "validation"
+	ValidationErrorMessage = "The data of your request is invalid."
+
+	// ErrValidation is the reason of the errors returned by `Request.Data`
+	// and `Request.Bind` when the validator fails.
+	ErrValidation = errors.New("validation failed")
+)
+
+// Validator validates the data read by `Request.Data` and `Request.Bind`.
+//
+// The fields reported by errors implementing `errors.ErrorWithValidation`, or
+// by the `validator.ValidationErrors` of go-playground/validator, are listed
+// on the response.
+type Validator interface {
+	Validate(v interface{}) error
+}
+
+// ValidatorFunc adapts a function into a `Validator`, eg.
+// `hermes.ValidatorFunc(validator.New().Struct)`.
+type ValidatorFunc func(v interface{}) error
+
+func (fn ValidatorFunc) Validate(v interface{}) error {
+	return fn(v)
+}
+
+// ValidationError is returned when the validator fails. It is sent as a 422
+// by `Response.Error`, listing the errors of each field.
+type ValidationError struct {
+	reason error
+	errors map[string][]string
+}
+
+func newValidationError(reason error) *ValidationError {
+	err := &ValidationError{
+		reason: reason,
+	}
+	if fields, ok := reason.(errors.ErrorWithValidation); ok {
+		err.errors = fields.Errors()
+	} else {
+		// Lists the fields of go-playground/validator errors
+		err.errors = errors.WrapValidation(reason).(errors.ErrorWithValidation).Errors()
+	}
+	return err
+}
+
+// Errors returns the errors of each field, eg. `{"name": ["required"]}`.
+func (err *ValidationError) Errors() map[string][]string {
+	return err.errors
+}
+
+// Reason returns the error returned by the validator.
+func (err *ValidationError) Reason() error {
+	return err.reason
+}
+
+func (err *ValidationError) Code() string {
+	return ValidationErrorCode
+}
+
+func (err *ValidationError) Message() string {
+	return ValidationErrorMessage
+}
+
+func (err *ValidationError) AppendData(response errors.ErrorResponse) {
+	response.SetParam("code", err.Code())
+	response.SetParam("message", err.Message())
+	response.SetParam("statusCode", StatusUnprocessableEntity)
+	if len(err.errors) > 0 {
+		response.SetParam("errors", err.errors)
+	}
+}
+
+func (err *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+func (err *ValidationError) Error() string {
+	if len(err.errors) == 0 {
+		return fmt.Sprintf("%s: %s", ErrValidation.Error(), err.reason.Error())
+	}
+
+	fields := make([]string, 0, len(err.errors))
+	for field := range err.errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for i, field := range fields {
+		fields[i] = fmt.Sprintf(`"%s" failed on %s`, field, strings.Join(err.errors[field], ", "))
+	}
+	return fmt.Sprintf("%s: %s", ErrValidation.Error(), strings.Join(fields, "; "))
+}
+
+// validate validates `dst` when it points to a struct.
+func validate(validator Validator, dst interface{}) error {
+	if validator == nil {
+		return nil
+	}
+	if t := reflect.TypeOf(dst); t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if err := validator.Validate(dst); err != nil {
+		return newValidationError(err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package hermes
+
+import (
+	"encoding/json"
+
+	"github.com/lab259/errors/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type validatedTodo struct {
+	ID          int64  `param:"id"`
+	Description string `json:"description" validate:"required"`
+	Priority    int    `json:"priority" query:"priority" validate:"min=1,max=5"`
+}
+
+type fieldsError map[string][]string
+
+func (err fieldsError) Error() string {
+	return "invalid fields"
+}
+
+func (err fieldsError) Errors() map[string][]string {
+	return err
+}
+
+var _ = Describe("Hermes", func() {
+	Describe("Validator", func() {
+		var (
+			router Router
+			err    error
+		)
+
+		responseBody := func(body []byte) map[string]interface{} {
+			var data map[string]interface{}
+			ExpectWithOffset(1, json.Unmarshal(body, &data)).To(Succeed())
+			return data
+		}
+
+		BeforeEach(func() {
+			err = nil
+			router = NewRouter(RouterConfig{
+				Validator: ValidatorFunc(validator.New().Struct),
+			})
+			router.Post("/todos", func(req Request, res Response) Result {
+				var todo validatedTodo
+				if err = req.Data(&todo); err != nil {
+					return res.Error(err)
+				}
+				return res.Data(&todo)
+			})
+			router.Put("/todos/:id", func(req Request, res Response) Result {
+				var todo validatedTodo
+				if err = req.Bind(&todo); err != nil {
+					return res.Error(err)
+				}
+				return res.Data(&todo)
+			})
+		})
+
+		It("should validate the data", func() {
+			ctx := createRequestCtxFromPath("POST", "/todos")
+			ctx.Request.SetBodyString(`{"priority": 7}`)
+			router.Handler()(ctx)
+
+			Expect(errors.Is(err, ErrValidation)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`validation failed: "Description" failed on required; "Priority" failed on max`))
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusUnprocessableEntity))
+			Expect(responseBody(ctx.Response.Body())).To(Equal(map[string]interface{}{
+				"code":    ValidationErrorCode,
+				"message": ValidationErrorMessage,
+				"errors": map[string]interface{}{
+					"Description": []interface{}{"required"},
+					"Priority":    []interface{}{"max"},
+				},
+			}))
+		})
+
+		It("should validate the bound data", func() {
+			ctx := createRequestCtxFromPath("PUT", "/todos/1")
+			ctx.Request.Header.SetContentType("application/json")
+			ctx.Request.SetBodyString(`{"description": "write tests"}`)
+			ctx.Request.URI().SetQueryString("priority=0")
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusUnprocessableEntity))
+			Expect(err.(*ValidationError).Errors()).To(Equal(map[string][]string{"Priority": {"min"}}))
+
+			ctx = createRequestCtxFromPath("PUT", "/todos/1")
+			ctx.Request.Header.SetContentType("application/json")
+			ctx.Request.SetBodyString(`{"description": "write tests"}`)
+			ctx.Request.URI().SetQueryString("priority=3")
+			router.Handler()(ctx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusOK))
+		})
+
+		It("should not validate data that failed to bind", func() {
+			ctx := createRequestCtxFromPath("PUT", "/todos/first")
+			router.Handler()(ctx)
+
+			Expect(errors.Is(err, ErrBind)).To(BeTrue())
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusBadRequest))
+		})
+
+		It("should list the fields of custom validators", func() {
+			router := NewRouter(RouterConfig{
+				Validator: ValidatorFunc(func(v interface{}) error {
+					return fieldsError{"description": {"too short"}}
+				}),
+			})
+			router.Post("/todos", func(req Request, res Response) Result {
+				var todo validatedTodo
+				return res.Error(req.Data(&todo))
+			})
+
+			ctx := createRequestCtxFromPath("POST", "/todos")
+			ctx.Request.SetBodyString(`{"description": "a"}`)
+			router.Handler()(ctx)
+
+			Expect(ctx.Response.StatusCode()).To(Equal(StatusUnprocessableEntity))
+			Expect(responseBody(ctx.Response.Body())).To(HaveKeyWithValue("errors", map[string]interface{}{
+				"description": []interface{}{"too short"},
+			}))
+		})
+
+		It("should report the reason of validators not listing fields", func() {
+			reason := errors.New("too late to create todos")
+			err := validate(ValidatorFunc(func(v interface{}) error {
+				return reason
+			}), &validatedTodo{})
+
+			Expect(err.(*ValidationError).Reason()).To(Equal(reason))
+			Expect(err.(*ValidationError).Errors()).To(BeEmpty())
+			Expect(err.Error()).To(Equal("validation failed: too late to create todos"))
+		})
+
+		It("should validate only structs", func() {
+			calls := 0
+			v := ValidatorFunc(func(v interface{}) error {
+				calls++
+				return nil
+			})
+
+			Expect(validate(v, &map[string]interface{}{})).To(Succeed())
+			Expect(validate(v, validatedTodo{})).To(Succeed())
+			Expect(validate(v, nil)).To(Succeed())
+			Expect(calls).To(BeZero())
+
+			Expect(validate(v, &validatedTodo{})).To(Succeed())
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should use the validator of the mounted router", func() {
+			sub := NewRouter(RouterConfig{
+				Validator: ValidatorFunc(func(v interface{}) error {
+					return errors.New("sub validator")
+				}),
+			})
+			sub.Post("/", func(req Request, res Response) Result {
+				var todo validatedTodo
+				err = req.Data(&todo)
+				return res.Error(err)
+			})
+			router.Mount("/api/todos", sub)
+
+			ctx := createRequestCtxFromPath("POST", "/api/todos")
+			ctx.Request.SetBodyString(`{"description": "write tests", "priority": 1}`)
+			router.Handler()(ctx)
+
+			Expect(err.(*ValidationError).Reason()).To(MatchError("sub validator"))
+		})
+
+		It("should use the validator of the application when the router has none", func() {
+			failing := ValidatorFunc(func(v interface{}) error {
+				return errors.New("application validator")
+			})
+			handler := func(req Request, res Response) Result {
+				var todo validatedTodo
+				err = req.Data(&todo)
+				return res.Error(err)
+			}
+
+			r := DefaultRouter()
+			r.Post("/todos", handler)
+			app := NewApplication(ApplicationConfig{Validator: failing}, r)
+			ctx := createRequestCtxFromPath("POST", "/todos")
+			ctx.Request.SetBodyString(`{"description": "write tests", "priority": 1}`)
+			app.fasthttpService.Server.Handler(ctx)
+			Expect(err.(*ValidationError).Reason()).To(MatchError("application validator"))
+
+			router.Post("/other", handler)
+			app = NewApplication(ApplicationConfig{Validator: failing}, router)
+			ctx = createRequestCtxFromPath("POST", "/other")
+			ctx.Request.SetBodyString(`{"description": "write tests", "priority": 1}`)
+			app.fasthttpService.Server.Handler(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})